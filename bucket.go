@@ -3,15 +3,36 @@
 package oss
 
 import (
+	"encoding/xml"
+	"fmt"
 	"net/http"
+	"strings"
+	"time"
 )
 
+// OSS Lifecycle Rule Status List
+const (
+	LifecycleEnabled  = "Enabled"
+	LifecycleDisabled = "Disabled"
+)
+
+// OSS Website Routing Rule Redirect Type List
+const (
+	RedirectMirror   = "Mirror"
+	RedirectExternal = "External"
+	RedirectInternal = "Internal"
+	RedirectAliCDN   = "AliCDN"
+)
+
+const lifecycleDateFormat = "2006-01-02T15:04:05.000Z"
+
 // Bucket represents a OSS bucket, the Name is required.
 type Bucket struct {
 	Service
-	Name     string
-	ACL      string
-	Location string
+	Name         string
+	ACL          string
+	Location     string
+	StorageClass string
 }
 
 // NewObject returns a new Object given a objectName from the bucket.
@@ -58,7 +79,7 @@ func (b Bucket) GetRequest(method, object string, body interface{}, args ...Para
 	return b.Service.GetRequest(method, b.Name, object, body, args...)
 }
 
-// Put create the buckect also send the ACL and the location if they are not the empty string.
+// Put create the buckect also send the ACL, the location and the storage class if they are not the empty string.
 //
 // The first optional Params is for Header, the second is for Query.
 //
@@ -71,8 +92,11 @@ func (b Bucket) Put(args ...Params) error {
 		header.Set("x-oss-acl", b.ACL)
 	}
 	var body interface{}
-	if b.Location != "" {
-		body = CreateBucketConfiguration{b.Location}
+	if b.Location != "" || b.StorageClass != "" {
+		body = CreateBucketConfiguration{
+			LocationConstraint: b.Location,
+			StorageClass:       b.StorageClass,
+		}
 	}
 	return b.Do("PUT", "", body, nil, header, query)
 }
@@ -110,10 +134,15 @@ func (b Bucket) PutLogging(bls BucketLoggingStatus, args ...Params) error {
 //
 // The first optional Params is for Header, the second is for Query.
 //
+// The configuration is validated by the method WebsiteConfiguration.Validate before send.
+//
 // Relevant documentation:
 //
 // https://docs.aliyun.com/#/pub/oss/api-reference/bucket&PutBucketWebsite
 func (b Bucket) PutWebsite(wc WebsiteConfiguration, args ...Params) error {
+	if err := wc.Validate(); err != nil {
+		return err
+	}
 	header, query := getHeaderQuery(args)
 	query.Set("website", "")
 	return b.Do("PUT", "", wc, nil, header, query)
@@ -137,15 +166,47 @@ func (b Bucket) PutReferer(rc RefererConfiguration, args ...Params) error {
 //
 // The first optional Params is for Header, the second is for Query.
 //
+// The configuration is validated by the method LifecycleConfiguration.Validate before send.
+//
 // Relevant documentation:
 //
 // https://docs.aliyun.com/#/pub/oss/api-reference/bucket&PutBucketLifecycle
 func (b Bucket) PutLifecycle(lc LifecycleConfiguration, args ...Params) error {
+	if err := lc.Validate(); err != nil {
+		return err
+	}
 	header, query := getHeaderQuery(args)
 	query.Set("lifecycle", "")
 	return b.Do("PUT", "", lc, nil, header, query)
 }
 
+// PutReplication add a cross-region replication rule of the bucket.
+//
+// The first optional Params is for Header, the second is for Query.
+//
+// Relevant documentation:
+//
+// https://help.aliyun.com/document_detail/181408.html
+func (b Bucket) PutReplication(rc ReplicationConfiguration, args ...Params) error {
+	header, query := getHeaderQuery(args)
+	query.Set("replication", "")
+	query.Set("comp", "add")
+	return b.Do("POST", "", rc, nil, header, query)
+}
+
+// PutResourceGroup move the bucket to the resource group given the resource group id.
+//
+// The first optional Params is for Header, the second is for Query.
+//
+// Relevant documentation:
+//
+// https://help.aliyun.com/document_detail/196013.html
+func (b Bucket) PutResourceGroup(resourceGroupId string, args ...Params) error {
+	header, query := getHeaderQuery(args)
+	query.Set("resourceGroup", "")
+	return b.Do("PUT", "", BucketResourceGroupConfiguration{resourceGroupId}, nil, header, query)
+}
+
 // ListObject returns the objects information of the bucket.
 //
 // The first optional Params is for Header, the second is for Query.
@@ -286,6 +347,133 @@ func (b Bucket) GetLifecycle(args ...Params) (*LifecycleConfiguration, error) {
 	return v, nil
 }
 
+// GetInfo returns the bucket information, such as the creation date, the endpoints,
+// the owner, the ACL, the storage class, the versioning and the encryption.
+//
+// The first optional Params is for Header, the second is for Query.
+//
+// Relevant documentation:
+//
+// https://help.aliyun.com/document_detail/31968.html
+func (b Bucket) GetInfo(args ...Params) (*BucketInfo, error) {
+	header, query := getHeaderQuery(args)
+	query.Set("bucketInfo", "")
+
+	var v struct {
+		Bucket BucketInfo
+	}
+
+	err := b.Do("GET", "", nil, &v, header, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return &v.Bucket, nil
+}
+
+// GetStat returns the bucket statistics, such as the storage size,
+// the object count and the multipart upload count.
+//
+// The first optional Params is for Header, the second is for Query.
+//
+// The statistics are not real-time, usually delay more than one hour.
+//
+// Relevant documentation:
+//
+// https://help.aliyun.com/document_detail/426056.html
+func (b Bucket) GetStat(args ...Params) (*BucketStat, error) {
+	header, query := getHeaderQuery(args)
+	query.Set("stat", "")
+
+	v := new(BucketStat)
+
+	err := b.Do("GET", "", nil, v, header, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+// GetReplication returns the cross-region replication rules of the bucket.
+//
+// The first optional Params is for Header, the second is for Query.
+//
+// Relevant documentation:
+//
+// https://help.aliyun.com/document_detail/181409.html
+func (b Bucket) GetReplication(args ...Params) (*ReplicationConfiguration, error) {
+	header, query := getHeaderQuery(args)
+	query.Set("replication", "")
+
+	v := new(ReplicationConfiguration)
+
+	err := b.Do("GET", "", nil, v, header, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+// GetReplicationLocation returns the locations which the bucket can replicate to.
+//
+// The first optional Params is for Header, the second is for Query.
+//
+// Relevant documentation:
+//
+// https://help.aliyun.com/document_detail/181410.html
+func (b Bucket) GetReplicationLocation(args ...Params) (*ReplicationLocation, error) {
+	header, query := getHeaderQuery(args)
+	query.Set("replicationLocation", "")
+
+	v := new(ReplicationLocation)
+
+	err := b.Do("GET", "", nil, v, header, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+// GetReplicationProgress returns the cross-region replication progress given a rule id.
+//
+// The first optional Params is for Header, the second is for Query.
+//
+// Relevant documentation:
+//
+// https://help.aliyun.com/document_detail/181411.html
+func (b Bucket) GetReplicationProgress(ruleId string, args ...Params) (*ReplicationProgress, error) {
+	header, query := getHeaderQuery(args)
+	query.Set("replicationProgress", "")
+	query.Set("rule-id", ruleId)
+
+	v := new(ReplicationProgress)
+
+	err := b.Do("GET", "", nil, v, header, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+// GetResourceGroup returns the resource group id of the bucket.
+//
+// The first optional Params is for Header, the second is for Query.
+//
+// Relevant documentation:
+//
+// https://help.aliyun.com/document_detail/196014.html
+func (b Bucket) GetResourceGroup(args ...Params) (string, error) {
+	header, query := getHeaderQuery(args)
+	query.Set("resourceGroup", "")
+	var v BucketResourceGroupConfiguration
+	err := b.Do("GET", "", nil, &v, header, query)
+	return v.ResourceGroupId, err
+}
+
 // Delete the bucket, returns BucketNotEmpty Error if the buckect is not empty.
 //
 // The first optional Params is for Header, the second is for Query.
@@ -336,6 +524,21 @@ func (b Bucket) DeleteLifecycle(args ...Params) error {
 	return b.Do("DELETE", "", nil, nil, header, query)
 }
 
+// DeleteReplication stop the cross-region replication given a rule id,
+// the already replicated objects are kept in the destination bucket.
+//
+// The first optional Params is for Header, the second is for Query.
+//
+// Relevant documentation:
+//
+// https://help.aliyun.com/document_detail/181412.html
+func (b Bucket) DeleteReplication(ruleId string, args ...Params) error {
+	header, query := getHeaderQuery(args)
+	query.Set("replication", "")
+	query.Set("comp", "delete")
+	return b.Do("POST", "", ReplicationRules{ruleId}, nil, header, query)
+}
+
 // DeleteObjects delete multiple objects by the keys, if not quiet returns the deleted objects.
 //
 // The first optional Params is for Header, the second is for Query.
@@ -381,7 +584,68 @@ func (b Bucket) DeleteObjects(keys []string, quiet bool, args ...Params) ([]stri
 //
 // https://docs.aliyun.com/#/pub/oss/api-reference/bucket&PutBucket
 type CreateBucketConfiguration struct {
-	LocationConstraint string
+	LocationConstraint string `xml:",omitempty"`
+	StorageClass       string `xml:",omitempty"` // Standard, IA, Archive
+}
+
+// BucketInfo represents the bucket information.
+//
+// Relevant documentation:
+//
+// https://help.aliyun.com/document_detail/31968.html
+type BucketInfo struct {
+	Name               string
+	Location           string
+	CreationDate       string // 2006-01-02T15:04:05.000Z
+	ExtranetEndpoint   string
+	IntranetEndpoint   string
+	StorageClass       string
+	Versioning         string
+	ResourceGroupId    string
+	DataRedundancyType string
+	Comment            string
+	Owner              Owner
+	AccessControlList  struct {
+		Grant string
+	}
+	ServerSideEncryptionRule struct {
+		SSEAlgorithm   string // None, AES256, KMS
+		KMSMasterKeyID string
+	}
+	BucketPolicy struct {
+		LogBucket string
+		LogPrefix string
+	}
+}
+
+// BucketStat represents the bucket statistics, the storage sizes are in bytes.
+//
+// Relevant documentation:
+//
+// https://help.aliyun.com/document_detail/426056.html
+type BucketStat struct {
+	Storage                     int64
+	ObjectCount                 int64
+	MultipartUploadCount        int64
+	LiveChannelCount            int64
+	LastModifiedTime            int64 // unix timestamp
+	StandardStorage             int64
+	StandardObjectCount         int64
+	InfrequentAccessStorage     int64
+	InfrequentAccessRealStorage int64
+	InfrequentAccessObjectCount int64
+	ArchiveStorage              int64
+	ArchiveRealStorage          int64
+	ArchiveObjectCount          int64
+}
+
+// BucketResourceGroupConfiguration represents the resource group of the bucket.
+//
+// Relevant documentation:
+//
+// https://help.aliyun.com/document_detail/196013.html
+type BucketResourceGroupConfiguration struct {
+	ResourceGroupId string
 }
 
 // BucketLoggingStatus represents the logging status.
@@ -405,11 +669,173 @@ type BucketLoggingStatus struct {
 // https://docs.aliyun.com/#/pub/oss/api-reference/bucket&GetBucketWebsite
 type WebsiteConfiguration struct {
 	IndexDocument struct {
-		Suffix string
+		Suffix        string
+		SupportSubDir bool `xml:",omitempty"`
+		Type          int  `xml:",omitempty"` // 0 redirect, 1 index document, 2 error document
 	}
 	ErrorDocument struct {
-		Key string
+		Key        string
+		HttpStatus int `xml:",omitempty"`
+	}
+	RoutingRules RoutingRules
+}
+
+// RoutingRuleIncludeHeader represents a header condition of the routing rule.
+type RoutingRuleIncludeHeader struct {
+	Key    string
+	Equals string
+}
+
+// RoutingRuleCondition represents the condition of the routing rule,
+// at least one of the KeyPrefixEquals and the HttpErrorCodeReturnedEquals is required.
+type RoutingRuleCondition struct {
+	KeyPrefixEquals             string                     `xml:",omitempty"`
+	HttpErrorCodeReturnedEquals int                        `xml:",omitempty"`
+	IncludeHeader               []RoutingRuleIncludeHeader `xml:",omitempty"`
+}
+
+// RoutingRuleMirrorHeaders represents the headers passed to the origin when mirror back.
+type RoutingRuleMirrorHeaders struct {
+	PassAll bool     `xml:",omitempty"`
+	Pass    []string `xml:",omitempty"`
+	Remove  []string `xml:",omitempty"`
+	Set     []struct {
+		Key   string
+		Value string
+	} `xml:",omitempty"`
+}
+
+// RoutingRuleRedirect represents the redirect of the routing rule.
+//
+// The Mirror fetches the object from the MirrorURL when the object not found,
+// the External and the AliCDN redirect the client to the HostName,
+// the Internal rewrite the object name in the same bucket.
+type RoutingRuleRedirect struct {
+	RedirectType          string                    // Mirror, External, Internal, AliCDN
+	PassQueryString       bool                      `xml:",omitempty"`
+	MirrorURL             string                    `xml:",omitempty"`
+	MirrorPassQueryString bool                      `xml:",omitempty"`
+	MirrorFollowRedirect  bool                      `xml:",omitempty"`
+	MirrorCheckMd5        bool                      `xml:",omitempty"`
+	MirrorHeaders         *RoutingRuleMirrorHeaders `xml:",omitempty"`
+	Protocol              string                    `xml:",omitempty"` // http, https
+	HostName              string                    `xml:",omitempty"`
+	HttpRedirectCode      int                       `xml:",omitempty"` // 301, 302, 307
+	ReplaceKeyPrefixWith  string                    `xml:",omitempty"`
+	ReplaceKeyWith        string                    `xml:",omitempty"`
+	EnableReplacePrefix   bool                      `xml:",omitempty"`
+}
+
+// RoutingRule represents a routing rule of the static site,
+// the rules are matched in the order of the RuleNumber.
+type RoutingRule struct {
+	RuleNumber int
+	Condition  RoutingRuleCondition
+	Redirect   RoutingRuleRedirect
+}
+
+// RoutingRules represents the routing rules of the static site,
+// it is not sent if it is empty.
+type RoutingRules []RoutingRule
+
+// MarshalXML omit the empty routing rules.
+func (v RoutingRules) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if len(v) == 0 {
+		return nil
+	}
+	return e.EncodeElement(struct {
+		RoutingRule []RoutingRule
+	}{v}, start)
+}
+
+// UnmarshalXML decodes the routing rules.
+func (v *RoutingRules) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var x struct {
+		RoutingRule []RoutingRule
+	}
+	if err := d.DecodeElement(&x, &start); err != nil {
+		return err
+	}
+	*v = append(*v, x.RoutingRule...)
+	return nil
+}
+
+// Validate returns an error if the configuration is invalid, the checks are:
+//
+// The IndexDocument.Type is 0, 1 or 2, at most 20 routing rules and the RuleNumbers are positive and unique.
+// Every condition has the KeyPrefixEquals or the HttpErrorCodeReturnedEquals,
+// which must be 404 for the Mirror.
+// The Mirror has the MirrorURL which is http or https and ends with the slash,
+// the External and the AliCDN have the HostName,
+// the HttpRedirectCode is 301, 302 or 307, the Protocol is http or https,
+// the ReplaceKeyWith and the ReplaceKeyPrefixWith are exclusive.
+func (v WebsiteConfiguration) Validate() error {
+	if t := v.IndexDocument.Type; t < 0 || t > 2 {
+		return errWebsiteIndexTypeInvalid
+	}
+	if len(v.RoutingRules) > 20 {
+		return errWebsiteRulesTooMany
+	}
+	numbers := make(map[int]bool, len(v.RoutingRules))
+	for _, r := range v.RoutingRules {
+		if r.RuleNumber <= 0 || numbers[r.RuleNumber] {
+			return fmt.Errorf("website routing rule %d: %w", r.RuleNumber, errWebsiteRuleNumberInvalid)
+		}
+		numbers[r.RuleNumber] = true
+		if err := r.validate(); err != nil {
+			return fmt.Errorf("website routing rule %d: %w", r.RuleNumber, err)
+		}
+	}
+	return nil
+}
+
+func (r RoutingRule) validate() error {
+	c, x := r.Condition, r.Redirect
+
+	if c.KeyPrefixEquals == "" && c.HttpErrorCodeReturnedEquals == 0 {
+		return errWebsiteConditionRequired
 	}
+	for _, i := range c.IncludeHeader {
+		if i.Key == "" {
+			return errWebsiteHeaderKeyRequired
+		}
+	}
+
+	switch x.RedirectType {
+	case RedirectMirror:
+		if c.HttpErrorCodeReturnedEquals != 404 {
+			return errWebsiteMirrorCodeInvalid
+		}
+		u := strings.ToLower(x.MirrorURL)
+		if !(strings.HasPrefix(u, "http://") || strings.HasPrefix(u, "https://")) || !strings.HasSuffix(u, "/") {
+			return errWebsiteMirrorURLInvalid
+		}
+	case RedirectExternal, RedirectAliCDN:
+		if x.HostName == "" {
+			return errWebsiteHostNameRequired
+		}
+	case RedirectInternal:
+		if x.MirrorURL != "" || x.HostName != "" {
+			return errWebsiteInternalInvalid
+		}
+	default:
+		return errWebsiteRedirectTypeInvalid
+	}
+
+	switch x.HttpRedirectCode {
+	case 0, 301, 302, 307:
+	default:
+		return errWebsiteRedirectCodeInvalid
+	}
+	switch x.Protocol {
+	case "", "http", "https":
+	default:
+		return errWebsiteProtocolInvalid
+	}
+	if x.ReplaceKeyWith != "" && (x.ReplaceKeyPrefixWith != "" || x.EnableReplacePrefix) {
+		return errWebsiteReplaceKeyExclusive
+	}
+	return nil
 }
 
 // RefererConfiguration represents the referer white list configuration.
@@ -425,15 +851,63 @@ type RefererConfiguration struct {
 	}
 }
 
+// LifecycleExpiration represents the expiration of the lifecycle rule,
+// it is not sent if all the fields are zero.
+//
+// The Date and the CreatedBeforeDate are the ISO8601 midnight, such as 2006-01-02T00:00:00.000Z.
+type LifecycleExpiration struct {
+	Date                      string `xml:",omitempty"`
+	Days                      int    `xml:",omitempty"`
+	CreatedBeforeDate         string `xml:",omitempty"`
+	ExpiredObjectDeleteMarker bool   `xml:",omitempty"`
+}
+
+// MarshalXML omit the zero expiration.
+func (v LifecycleExpiration) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if v == (LifecycleExpiration{}) {
+		return nil
+	}
+	type expiration LifecycleExpiration
+	return e.EncodeElement(expiration(v), start)
+}
+
+// LifecycleTransition represents the storage class transition of the lifecycle rule.
+type LifecycleTransition struct {
+	Days              int    `xml:",omitempty"`
+	CreatedBeforeDate string `xml:",omitempty"`
+	StorageClass      string // IA, Archive
+}
+
+// LifecycleAbortMultipartUpload represents the expiration of the not complete Multipart Uploads.
+type LifecycleAbortMultipartUpload struct {
+	Days              int    `xml:",omitempty"`
+	CreatedBeforeDate string `xml:",omitempty"`
+}
+
+// LifecycleNoncurrentVersionExpiration represents the expiration of the noncurrent versions.
+type LifecycleNoncurrentVersionExpiration struct {
+	NoncurrentDays int
+}
+
+// LifecycleNoncurrentVersionTransition represents the storage class transition of the noncurrent versions.
+type LifecycleNoncurrentVersionTransition struct {
+	NoncurrentDays int
+	StorageClass   string // IA, Archive
+}
+
 // LifecycleRule represents a rule of the objects lifecycle configuration.
+//
+// The rule applies to the objects which match the prefix and all the tags.
 type LifecycleRule struct {
-	ID         string `xml:",omitempty"`
-	Prefix     string
-	Status     string // Enabled, Disabled
-	Expiration struct {
-		Date string `xml:",omitempty"`
-		Days int    `xml:",omitempty"`
-	}
+	ID                          string `xml:",omitempty"`
+	Prefix                      string
+	Tag                         []Tag  `xml:",omitempty"`
+	Status                      string // Enabled, Disabled
+	Expiration                  LifecycleExpiration
+	Transition                  []LifecycleTransition                  `xml:",omitempty"`
+	AbortMultipartUpload        *LifecycleAbortMultipartUpload         `xml:",omitempty"`
+	NoncurrentVersionExpiration *LifecycleNoncurrentVersionExpiration  `xml:",omitempty"`
+	NoncurrentVersionTransition []LifecycleNoncurrentVersionTransition `xml:",omitempty"`
 }
 
 // LifecycleConfiguration represents the objects lifecycle configuration.
@@ -446,6 +920,201 @@ type LifecycleConfiguration struct {
 	Rule []LifecycleRule
 }
 
+// Validate returns an error if the configuration is invalid, the checks are:
+//
+// At least one and at most 1000 rules, the IDs are unique and at most 255 bytes.
+// The Status is Enabled or Disabled and every rule has at least one action.
+// Each action has exactly one of the days and the date,
+// the dates are the ISO8601 midnight and the days are positive.
+// The transition storage class is IA or Archive and the transitions are earlier than the expiration.
+// The AbortMultipartUpload can not be used with the tags.
+func (v LifecycleConfiguration) Validate() error {
+	if n := len(v.Rule); n == 0 || n > 1000 {
+		return errLifecycleRulesInvalid
+	}
+	ids := make(map[string]bool, len(v.Rule))
+	for k, r := range v.Rule {
+		if err := r.validate(); err != nil {
+			return fmt.Errorf("lifecycle rule %d: %w", k, err)
+		}
+		if r.ID != "" {
+			if ids[r.ID] {
+				return fmt.Errorf("lifecycle rule %d: %w", k, errLifecycleIdInvalid)
+			}
+			ids[r.ID] = true
+		}
+	}
+	return nil
+}
+
+func (r LifecycleRule) validate() error {
+	if len(r.ID) > 255 {
+		return errLifecycleIdInvalid
+	}
+	if r.Status != LifecycleEnabled && r.Status != LifecycleDisabled {
+		return errLifecycleStatusInvalid
+	}
+	if r.Expiration == (LifecycleExpiration{}) && len(r.Transition) == 0 &&
+		r.AbortMultipartUpload == nil && r.NoncurrentVersionExpiration == nil &&
+		len(r.NoncurrentVersionTransition) == 0 {
+		return errLifecycleActionRequired
+	}
+
+	x := r.Expiration
+	if x != (LifecycleExpiration{}) {
+		n := 0
+		for _, i := range []bool{x.Days != 0, x.Date != "", x.CreatedBeforeDate != "", x.ExpiredObjectDeleteMarker} {
+			if i {
+				n++
+			}
+		}
+		if n != 1 {
+			return errLifecycleExpirationInvalid
+		}
+		if x.ExpiredObjectDeleteMarker && len(r.Tag) > 0 {
+			return errLifecycleTagsNotAllowed
+		}
+		if err := validateLifecycleTime(x.Days, x.Date+x.CreatedBeforeDate, x.ExpiredObjectDeleteMarker); err != nil {
+			return err
+		}
+	}
+
+	for _, i := range r.Transition {
+		if err := validateLifecycleTime(i.Days, i.CreatedBeforeDate, false); err != nil {
+			return err
+		}
+		if i.StorageClass != StorageIA && i.StorageClass != StorageArchive {
+			return errLifecycleStorageClassInvalid
+		}
+		if x.Days > 0 && i.Days >= x.Days || x.CreatedBeforeDate != "" && i.CreatedBeforeDate >= x.CreatedBeforeDate {
+			return errLifecycleTransitionInvalid
+		}
+	}
+
+	if i := r.AbortMultipartUpload; i != nil {
+		if len(r.Tag) > 0 {
+			return errLifecycleTagsNotAllowed
+		}
+		if err := validateLifecycleTime(i.Days, i.CreatedBeforeDate, false); err != nil {
+			return err
+		}
+	}
+
+	if i := r.NoncurrentVersionExpiration; i != nil && i.NoncurrentDays <= 0 {
+		return errLifecycleDaysInvalid
+	}
+
+	for _, i := range r.NoncurrentVersionTransition {
+		if i.NoncurrentDays <= 0 {
+			return errLifecycleDaysInvalid
+		}
+		if i.StorageClass != StorageIA && i.StorageClass != StorageArchive {
+			return errLifecycleStorageClassInvalid
+		}
+		if e := r.NoncurrentVersionExpiration; e != nil && i.NoncurrentDays >= e.NoncurrentDays {
+			return errLifecycleTransitionInvalid
+		}
+	}
+
+	for _, i := range r.Tag {
+		if i.Key == "" {
+			return errLifecycleTagKeyRequired
+		}
+	}
+
+	return nil
+}
+
+func validateLifecycleTime(days int, date string, other bool) error {
+	switch {
+	case other:
+	case days != 0 && date != "", days == 0 && date == "":
+		return errLifecycleDaysDateInvalid
+	case days < 0:
+		return errLifecycleDaysInvalid
+	case date != "":
+		t, err := time.Parse(lifecycleDateFormat, date)
+		if err != nil || t.Hour() != 0 || t.Minute() != 0 || t.Second() != 0 || t.Nanosecond() != 0 {
+			return errLifecycleDateInvalid
+		}
+	}
+	return nil
+}
+
+// ReplicationDestination represents the destination of the replication rule.
+type ReplicationDestination struct {
+	Bucket       string
+	Location     string
+	TransferType string `xml:",omitempty"` // internal, oss_acc
+}
+
+// ReplicationRule represents a cross-region replication rule.
+//
+// The Action is ALL or PUT, the HistoricalObjectReplication is enabled or disabled,
+// the Status is only in the result, starting, doing or closing.
+type ReplicationRule struct {
+	ID                          string   `xml:",omitempty"`
+	Prefix                      []string `xml:"PrefixSet>Prefix,omitempty"`
+	Action                      string   `xml:",omitempty"`
+	Destination                 ReplicationDestination
+	Status                      string `xml:",omitempty"`
+	HistoricalObjectReplication string `xml:",omitempty"`
+	SyncRole                    string `xml:",omitempty"`
+}
+
+// ReplicationConfiguration represents the cross-region replication configuration.
+//
+// Relevant documentation:
+//
+// https://help.aliyun.com/document_detail/181408.html
+type ReplicationConfiguration struct {
+	Rule []ReplicationRule
+}
+
+// ReplicationRules represents the replication rule ids to delete.
+//
+// Relevant documentation:
+//
+// https://help.aliyun.com/document_detail/181412.html
+type ReplicationRules struct {
+	ID string
+}
+
+// ReplicationLocation represents the locations which the bucket can replicate to.
+//
+// Relevant documentation:
+//
+// https://help.aliyun.com/document_detail/181410.html
+type ReplicationLocation struct {
+	Location                       []string
+	LocationTransferTypeConstraint struct {
+		LocationTransferType []struct {
+			Location      string
+			TransferTypes struct {
+				Type []string
+			}
+		}
+	}
+}
+
+// ReplicationProgress represents the cross-region replication progress.
+//
+// The HistoricalObject is the replicated percentage of the historical objects, such as 0.85,
+// the NewObject is the time before which the new objects are replicated.
+//
+// Relevant documentation:
+//
+// https://help.aliyun.com/document_detail/181411.html
+type ReplicationProgress struct {
+	Rule []struct {
+		ReplicationRule
+		Progress struct {
+			HistoricalObject string
+			NewObject        string
+		}
+	}
+}
+
 // ListBucketResult represents the get bucket result.
 //
 // Relevant documentation:
@@ -467,7 +1136,7 @@ type ListBucketResult struct {
 		StorageClass string
 		Owner        Owner
 	}
-	CommonPrefixes struct {
+	CommonPrefixes []struct {
 		Prefix string
 	}
 	NextMarker   string
@@ -475,8 +1144,13 @@ type ListBucketResult struct {
 }
 
 // DeleteObject represents a delete object.
+//
+// The VersionId is optional, the DeleteMarker and the DeleteMarkerVersionId are only in the result.
 type DeleteObject struct {
-	Key string
+	Key                   string
+	VersionId             string `xml:",omitempty"`
+	DeleteMarker          bool   `xml:",omitempty"`
+	DeleteMarkerVersionId string `xml:",omitempty"`
 }
 
 // Delete represents the delete objects.
@@ -3,6 +3,9 @@
 package oss
 
 import (
+	"encoding/xml"
+	"errors"
+	"strings"
 	"testing"
 )
 
@@ -188,3 +191,205 @@ func TestBucketLifecycle(t *testing.T) {
 
 	fatal(t, b.Delete())
 }
+
+func TestLifecycleValidate(t *testing.T) {
+	r := LifecycleRule{
+		ID:     "archive logs",
+		Prefix: "logs/",
+		Status: LifecycleEnabled,
+		Transition: []LifecycleTransition{
+			{Days: 30, StorageClass: StorageIA},
+			{Days: 60, StorageClass: StorageArchive},
+		},
+		AbortMultipartUpload: &LifecycleAbortMultipartUpload{Days: 7},
+	}
+	v := LifecycleConfiguration{Rule: []LifecycleRule{r}}
+	fatal(t, v.Validate())
+
+	b, err := xml.Marshal(v)
+	fatal(t, err)
+	if strings.Contains(string(b), "<Expiration>") {
+		t.Fatal("expected no Expiration", string(b))
+	}
+
+	r.Expiration.Days = 365
+	v.Rule[0] = r
+	fatal(t, v.Validate())
+
+	b, err = xml.Marshal(v)
+	fatal(t, err)
+	if !strings.Contains(string(b), "<Expiration><Days>365</Days></Expiration>") {
+		t.Fatal("expected Expiration", string(b))
+	}
+
+	for what, c := range map[string]struct {
+		f   func(*LifecycleRule)
+		err error
+	}{
+		"status":        {func(r *LifecycleRule) { r.Status = "On" }, errLifecycleStatusInvalid},
+		"no action":     {func(r *LifecycleRule) { *r = LifecycleRule{Status: LifecycleEnabled} }, errLifecycleActionRequired},
+		"days and date": {func(r *LifecycleRule) { r.Expiration.Date = "2016-01-02T00:00:00.000Z" }, errLifecycleExpirationInvalid},
+		"not midnight": {func(r *LifecycleRule) {
+			r.Expiration = LifecycleExpiration{CreatedBeforeDate: "2016-01-02T08:00:00.000Z"}
+		}, errLifecycleDateInvalid},
+		"storage class":  {func(r *LifecycleRule) { r.Transition[0].StorageClass = StorageStandard }, errLifecycleStorageClassInvalid},
+		"transition day": {func(r *LifecycleRule) { r.Transition[1].Days = 365 }, errLifecycleTransitionInvalid},
+		"abort tag":      {func(r *LifecycleRule) { r.Tag = []Tag{{"k", "v"}} }, errLifecycleTagsNotAllowed},
+		"noncurrent": {func(r *LifecycleRule) {
+			r.NoncurrentVersionExpiration = &LifecycleNoncurrentVersionExpiration{}
+		}, errLifecycleDaysInvalid},
+	} {
+		x := r
+		x.Transition = append([]LifecycleTransition(nil), r.Transition...)
+		c.f(&x)
+		v := LifecycleConfiguration{Rule: []LifecycleRule{x}}
+		if err := v.Validate(); !errors.Is(err, c.err) {
+			t.Fatal("expected error", what, err)
+		}
+	}
+
+	v.Rule = append(v.Rule, r)
+	err = v.Validate()
+	equal(t, "duplicate id", true, errors.Is(err, errLifecycleIdInvalid))
+	equal(t, "rule index", "lifecycle rule 1: "+errLifecycleIdInvalid.Error(), err.Error())
+
+	equal(t, "no rules", errLifecycleRulesInvalid, (LifecycleConfiguration{}).Validate())
+}
+
+func TestWebsiteValidate(t *testing.T) {
+	v := WebsiteConfiguration{}
+	v.IndexDocument.Suffix = "index.html"
+	v.IndexDocument.SupportSubDir = true
+	v.IndexDocument.Type = 1
+	v.ErrorDocument.Key = "error.html"
+	v.RoutingRules = RoutingRules{
+		{
+			RuleNumber: 1,
+			Condition:  RoutingRuleCondition{HttpErrorCodeReturnedEquals: 404},
+			Redirect: RoutingRuleRedirect{
+				RedirectType: RedirectMirror,
+				MirrorURL:    "http://www.cxr29.com/",
+			},
+		},
+		{
+			RuleNumber: 2,
+			Condition:  RoutingRuleCondition{KeyPrefixEquals: "abc/"},
+			Redirect: RoutingRuleRedirect{
+				RedirectType:     RedirectExternal,
+				Protocol:         "https",
+				HostName:         "www.cxr29.com",
+				HttpRedirectCode: 302,
+			},
+		},
+	}
+	fatal(t, v.Validate())
+
+	b, err := xml.Marshal(v)
+	fatal(t, err)
+	if !strings.Contains(string(b), "<RoutingRules><RoutingRule><RuleNumber>1</RuleNumber>") {
+		t.Fatal("expected RoutingRules", string(b))
+	}
+
+	x := WebsiteConfiguration{}
+	fatal(t, xml.Unmarshal(b, &x))
+	equal(t, "RoutingRule", 2, len(x.RoutingRules))
+	equal(t, "MirrorURL", "http://www.cxr29.com/", x.RoutingRules[0].Redirect.MirrorURL)
+
+	b, err = xml.Marshal(WebsiteConfiguration{})
+	fatal(t, err)
+	if strings.Contains(string(b), "RoutingRules") {
+		t.Fatal("expected no RoutingRules", string(b))
+	}
+
+	for what, c := range map[string]struct {
+		f   func(*RoutingRule)
+		err error
+	}{
+		"rule number":   {func(r *RoutingRule) { r.RuleNumber = 0 }, errWebsiteRuleNumberInvalid},
+		"condition":     {func(r *RoutingRule) { r.Condition = RoutingRuleCondition{} }, errWebsiteConditionRequired},
+		"mirror code":   {func(r *RoutingRule) { r.Condition.HttpErrorCodeReturnedEquals = 403 }, errWebsiteMirrorCodeInvalid},
+		"mirror url":    {func(r *RoutingRule) { r.Redirect.MirrorURL = "ftp://www.cxr29.com" }, errWebsiteMirrorURLInvalid},
+		"redirect type": {func(r *RoutingRule) { r.Redirect.RedirectType = "Proxy" }, errWebsiteRedirectTypeInvalid},
+		"redirect code": {func(r *RoutingRule) { r.Redirect.HttpRedirectCode = 303 }, errWebsiteRedirectCodeInvalid},
+		"replace key": {func(r *RoutingRule) {
+			r.Redirect.ReplaceKeyWith = "a"
+			r.Redirect.ReplaceKeyPrefixWith = "b"
+		}, errWebsiteReplaceKeyExclusive},
+	} {
+		x := v
+		x.RoutingRules = append(RoutingRules(nil), v.RoutingRules...)
+		c.f(&x.RoutingRules[0])
+		if err := x.Validate(); !errors.Is(err, c.err) {
+			t.Fatal("expected error", what, err)
+		}
+	}
+
+	v.IndexDocument.Type = 3
+	equal(t, "index type", errWebsiteIndexTypeInvalid, v.Validate())
+}
+
+func TestBucketInfoAndStat(t *testing.T) {
+	b := newBucket()
+
+	b.ACL = ACLPublicRead
+	b.StorageClass = StorageIA
+	fatal(t, b.Put())
+
+	v, err := b.GetInfo()
+	fatal(t, err)
+	equal(t, "Name", b.Name, v.Name)
+	equal(t, "Grant", ACLPublicRead, v.AccessControlList.Grant)
+	equal(t, "StorageClass", StorageIA, v.StorageClass)
+	if v.CreationDate == "" || v.ExtranetEndpoint == "" || v.IntranetEndpoint == "" {
+		t.Fatal("expected CreationDate and Endpoints")
+	}
+
+	s, err := b.GetStat()
+	fatal(t, err)
+	equal(t, "ObjectCount", int64(0), s.ObjectCount)
+
+	fatal(t, b.Delete())
+}
+
+func TestReplicationXML(t *testing.T) {
+	r := ReplicationRule{
+		Prefix: []string{"logs/", "data/"},
+		Action: "ALL",
+		Destination: ReplicationDestination{
+			Bucket:   "oss-example-backup",
+			Location: LocationCNBeijing,
+		},
+		HistoricalObjectReplication: "enabled",
+	}
+	b, err := xml.Marshal(ReplicationConfiguration{Rule: []ReplicationRule{r}})
+	fatal(t, err)
+	equal(t, "XML", "<ReplicationConfiguration><Rule><PrefixSet><Prefix>logs/</Prefix><Prefix>data/</Prefix></PrefixSet><Action>ALL</Action><Destination><Bucket>oss-example-backup</Bucket><Location>oss-cn-beijing</Location></Destination><HistoricalObjectReplication>enabled</HistoricalObjectReplication></Rule></ReplicationConfiguration>", string(b))
+
+	var v ReplicationProgress
+	fatal(t, xml.Unmarshal([]byte("<ReplicationProgress><Rule><ID>test_replication_1</ID><PrefixSet><Prefix>source_image</Prefix></PrefixSet><Action>PUT</Action><Destination><Bucket>target-bucket</Bucket><Location>oss-cn-beijing</Location></Destination><Status>doing</Status><HistoricalObjectReplication>enabled</HistoricalObjectReplication><Progress><HistoricalObject>0.85</HistoricalObject><NewObject>2015-09-24T15:28:14.000Z</NewObject></Progress></Rule></ReplicationProgress>"), &v))
+	equal(t, "Rule", 1, len(v.Rule))
+	equal(t, "ID", "test_replication_1", v.Rule[0].ID)
+	equal(t, "Prefix", "source_image", v.Rule[0].Prefix[0])
+	equal(t, "Status", "doing", v.Rule[0].Status)
+	equal(t, "HistoricalObject", "0.85", v.Rule[0].Progress.HistoricalObject)
+}
+
+func TestBucketReplicationLocation(t *testing.T) {
+	b := newBucket()
+
+	fatal(t, b.Put())
+
+	v, err := b.GetReplicationLocation()
+	fatal(t, err)
+	if len(v.Location) == 0 {
+		t.Fatal("expected Location")
+	}
+
+	_, err = b.GetReplication()
+	e, ok := err.(Error)
+	if !(ok && e.Code == "NoSuchReplicationConfiguration") {
+		t.Fatal("expected NoSuchReplicationConfiguration")
+	}
+
+	fatal(t, b.Delete())
+}
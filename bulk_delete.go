@@ -0,0 +1,175 @@
+// Copyright 2015 Chen Xianren. All rights reserved.
+
+package oss
+
+import (
+	"errors"
+	"net/url"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+// DeleteObjectsMaxKeys is the max number of the keys of a DeleteObjects request.
+const DeleteObjectsMaxKeys = 1000
+
+var (
+	errPrefixRequired = errors.New("prefix required")
+	errNotDeleted     = errors.New("object not in the delete result")
+)
+
+// BulkDeleteResult represents the bulk delete result, the keys are sorted.
+type BulkDeleteResult struct {
+	Deleted []string
+	Failed  []BulkDeleteFailure
+}
+
+// BulkDeleteFailure represents a failed key, the Code is the OSS error code if any.
+type BulkDeleteFailure struct {
+	Key  string
+	Code string
+	Err  error
+}
+
+// Err returns the first failure error, or nil if all the keys are deleted.
+func (r *BulkDeleteResult) Err() error {
+	if len(r.Failed) == 0 {
+		return nil
+	}
+	return r.Failed[0].Err
+}
+
+func (r *BulkDeleteResult) merge(v *BulkDeleteResult) {
+	r.Deleted = append(r.Deleted, v.Deleted...)
+	r.Failed = append(r.Failed, v.Failed...)
+}
+
+func (r *BulkDeleteResult) sort() {
+	sort.Strings(r.Deleted)
+	sort.Slice(r.Failed, func(i, j int) bool {
+		return r.Failed[i].Key < r.Failed[j].Key
+	})
+}
+
+// BulkDelete delete any number of objects by the keys,
+// the keys are split into the DeleteObjects requests of at most 1000 keys
+// and at most routines requests are sent in parallel.
+//
+// The first optional Params is for Header, the second is for Query.
+//
+// The keys of the response are URL encoded by the encoding-type url and decoded,
+// but the keys of the request are sent as the XML text, so the keys with the characters
+// which are invalid in XML, such as the control characters, are not supported,
+// they are failed as not deleted, delete them one by one by the method Object.Delete.
+//
+// All the keys of a failed request are failed with the request error,
+// the keys not in the response are failed too.
+//
+// Relevant documentation:
+//
+// https://docs.aliyun.com/#/pub/oss/api-reference/object&DeleteMultipleObjects
+func (b Bucket) BulkDelete(keys []string, routines int, args ...Params) *BulkDeleteResult {
+	var (
+		mu sync.Mutex
+		v  = new(BulkDeleteResult)
+	)
+
+	n := (len(keys) + DeleteObjectsMaxKeys - 1) / DeleteObjectsMaxKeys
+	parallel(n, routines, func(i int) error {
+		j := (i + 1) * DeleteObjectsMaxKeys
+		if j > len(keys) {
+			j = len(keys)
+		}
+		r := b.deleteBatch(keys[i*DeleteObjectsMaxKeys:j], args...)
+
+		mu.Lock()
+		v.merge(r)
+		mu.Unlock()
+		return nil
+	})
+
+	v.sort()
+	return v
+}
+
+// deleteBatch delete the keys by a DeleteObjects request.
+func (b Bucket) deleteBatch(keys []string, args ...Params) *BulkDeleteResult {
+	header, query := Params{}, Params{}
+	header.Copy(getParams(args, 0))
+	query.Copy(getParams(args, 1))
+	query.Set("delete", "")
+	query.Set("encoding-type", "url")
+
+	d := Delete{
+		Object: make([]DeleteObject, len(keys)),
+	}
+	for k, v := range keys {
+		d.Object[k].Key = v
+	}
+
+	var dr DeleteResult
+	err := b.Do("POST", "", d, &dr, header, query)
+
+	v := new(BulkDeleteResult)
+	if err != nil {
+		code := ErrorCode(err)
+		for _, k := range keys {
+			v.Failed = append(v.Failed, BulkDeleteFailure{k, code, err})
+		}
+		return v
+	}
+
+	deleted := make(map[string]bool, len(dr.Deleted))
+	for _, i := range dr.Deleted {
+		k, err := url.PathUnescape(i.Key)
+		if err != nil {
+			k = i.Key
+		}
+		deleted[k] = true
+	}
+	for _, k := range keys {
+		if deleted[k] {
+			v.Deleted = append(v.Deleted, k)
+		} else {
+			v.Failed = append(v.Failed, BulkDeleteFailure{k, "", errNotDeleted})
+		}
+	}
+	return v
+}
+
+// DeletePrefix delete all the objects which key starts with the prefix by the method BulkDelete,
+// the objects are listed page by page and deleted every routines pages.
+//
+// The first optional Params is for Header, the second is for Query,
+// both are sent with the DeleteObjects requests.
+//
+// Returns the listing error with the already deleted result.
+func (b Bucket) DeletePrefix(prefix string, routines int, args ...Params) (*BulkDeleteResult, error) {
+	if prefix == "" {
+		return nil, errPrefixRequired
+	}
+	if routines <= 0 {
+		routines = 1
+	}
+
+	var (
+		v    = new(BulkDeleteResult)
+		keys []string
+	)
+	it := b.NewObjectIterator(nil, Params{
+		"prefix":   {prefix},
+		"max-keys": {strconv.Itoa(DeleteObjectsMaxKeys)},
+	})
+	for n := 1; it.Next(); n++ {
+		for _, i := range it.Result().Contents {
+			keys = append(keys, i.Key)
+		}
+		if n%routines == 0 {
+			v.merge(b.BulkDelete(keys, routines, args...))
+			keys = nil
+		}
+	}
+	v.merge(b.BulkDelete(keys, routines, args...))
+	v.sort()
+	return v, it.Err()
+}
@@ -0,0 +1,37 @@
+// Copyright 2015 Chen Xianren. All rights reserved.
+
+package oss
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestBucketBulkDelete(t *testing.T) {
+	b := newBucket()
+
+	fatal(t, b.Put())
+
+	var keys []string
+	for i := 0; i < 5; i++ {
+		o := Object{Bucket: b, Name: "aliyun-oss-go-sdk/ +" + strconv.Itoa(i)}
+		_, err := o.Put([]byte(HelloWorld))
+		fatal(t, err)
+		keys = append(keys, o.Name)
+	}
+
+	r := b.BulkDelete(keys[:2], 2)
+	fatal(t, r.Err())
+	equal(t, "Deleted", 2, len(r.Deleted))
+	equal(t, "Deleted key", keys[0], r.Deleted[0])
+
+	r, err := b.DeletePrefix("aliyun-oss-go-sdk/", 2)
+	fatal(t, err)
+	fatal(t, r.Err())
+	equal(t, "DeletePrefix", 3, len(r.Deleted))
+
+	_, err = b.DeletePrefix("", 1)
+	equal(t, "empty prefix", errPrefixRequired, err)
+
+	fatal(t, b.Delete())
+}
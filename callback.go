@@ -0,0 +1,284 @@
+// Copyright 2015 Chen Xianren. All rights reserved.
+
+package oss
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/md5"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"encoding/xml"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Upload callback headers
+const (
+	HeaderCallback       = "x-oss-callback"
+	HeaderCallbackVar    = "x-oss-callback-var"
+	HeaderCallbackPubKey = "x-oss-pub-key-url"
+)
+
+// CallbackPublicKeyURLPrefixes are the trusted URL prefixes of the callback public key,
+// used by the default public key getter of the CallbackHandler.
+var CallbackPublicKeyURLPrefixes = []string{
+	"http://gosspublic.alicdn.com/",
+	"https://gosspublic.alicdn.com/",
+}
+
+// callbackPublicKeyClient fetches the callback public keys, the request times out after 10 seconds.
+var callbackPublicKeyClient = &http.Client{Timeout: 10 * time.Second}
+
+var (
+	errCallbackURLRequired        = errors.New("callback url required")
+	errCallbackSignatureInvalid   = errors.New("callback signature invalid")
+	errCallbackPublicKeyInvalid   = errors.New("callback public key invalid")
+	errCallbackPublicKeyUntrusted = errors.New("callback public key url untrusted")
+)
+
+// Callback represents the upload callback, OSS POST to the URL after the upload,
+// then returns the callback response body to the client.
+//
+// The Body supports the system variables such as ${bucket}, ${object}, ${etag}, ${size}, ${mimeType},
+// and the custom variables in the Var which key must start with "x:", such as ${x:uid}.
+//
+// The BodyType is application/x-www-form-urlencoded if it is the empty string.
+//
+// Relevant documentation:
+//
+// https://help.aliyun.com/document_detail/31989.html
+type Callback struct {
+	URL      string            `json:"callbackUrl"`
+	Host     string            `json:"callbackHost,omitempty"`
+	Body     string            `json:"callbackBody"`
+	BodyType string            `json:"callbackBodyType,omitempty"`
+	SNI      bool              `json:"callbackSNI,omitempty"`
+	Var      map[string]string `json:"-"`
+}
+
+// setHeader set the base64 JSON of the callback and the custom variables as the headers.
+func (cb Callback) setHeader(header Params) error {
+	if cb.URL == "" {
+		return errCallbackURLRequired
+	}
+	b, err := json.Marshal(cb)
+	if err != nil {
+		return err
+	}
+	header.Set(HeaderCallback, base64.StdEncoding.EncodeToString(b))
+	if len(cb.Var) > 0 {
+		b, err = json.Marshal(cb.Var)
+		if err != nil {
+			return err
+		}
+		header.Set(HeaderCallbackVar, base64.StdEncoding.EncodeToString(b))
+	}
+	return nil
+}
+
+// CallbackResult represents the upload with callback result,
+// the Body is the callback server response body.
+type CallbackResult struct {
+	ETag string
+	Body []byte
+}
+
+// readCallback reads the callback response, returns CallbackFailed Error
+// if the status code is 203, which means the upload is succeed but the callback is failed.
+func readCallback(res *http.Response) (*CallbackResult, error) {
+	v := &CallbackResult{ETag: res.Header.Get("ETag")}
+	err := ReadBody(res, &v.Body)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode == 203 {
+		var e Error
+		if err = xml.Unmarshal(v.Body, &e); err != nil {
+			return nil, err
+		}
+		return nil, e
+	}
+	return v, nil
+}
+
+// PutCallback is the same as the method Put and OSS calls back after the upload,
+// returns the ETag and the callback response body.
+//
+// The first optional Params is for Header, the second is for Query.
+//
+// Relevant documentation:
+//
+// https://help.aliyun.com/document_detail/31989.html
+func (o Object) PutCallback(data interface{}, cb Callback, args ...Params) (*CallbackResult, error) {
+	if !isPutDataType(data) {
+		return nil, errDataTypeNotSupported
+	}
+
+	header, query := getHeaderQuery(args)
+	if err := cb.setHeader(header); err != nil {
+		return nil, err
+	}
+	o.setPutHeader(header)
+
+	res, err := o.GetResponse("PUT", data, header, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return readCallback(res)
+}
+
+// CompleteMultipartUploadCallback is the same as the method CompleteMultipartUpload
+// and OSS calls back after the complete, returns the ETag and the callback response body.
+//
+// The first optional Params is for Header, the second is for Query.
+//
+// Relevant documentation:
+//
+// https://help.aliyun.com/document_detail/31989.html
+func (o Object) CompleteMultipartUploadCallback(uploadId string, parts CompleteMultipartUpload, cb Callback, args ...Params) (*CallbackResult, error) {
+	if uploadId == "" {
+		return nil, errUploadIdRequired
+	}
+
+	header, query := getHeaderQuery(args)
+	query.Set("uploadId", uploadId)
+	if err := cb.setHeader(header); err != nil {
+		return nil, err
+	}
+
+	res, err := o.GetResponse("POST", parts, header, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return readCallback(res)
+}
+
+// VerifyCallback verifies the OSS callback request signature given the request body and the public key.
+//
+// The signature is the base64 Authorization header,
+// which is the RSA MD5 signature of the URL decoded request path and query, "\n" and the body.
+func VerifyCallback(r *http.Request, body []byte, pub *rsa.PublicKey) error {
+	sig, err := base64.StdEncoding.DecodeString(r.Header.Get("Authorization"))
+	if err != nil || len(sig) == 0 {
+		return errCallbackSignatureInvalid
+	}
+	path, err := url.PathUnescape(r.URL.EscapedPath())
+	if err != nil {
+		return errCallbackSignatureInvalid
+	}
+	if r.URL.RawQuery != "" {
+		path += "?" + r.URL.RawQuery
+	}
+	h := md5.New()
+	h.Write([]byte(path + "\n"))
+	h.Write(body)
+	if rsa.VerifyPKCS1v15(pub, crypto.MD5, h.Sum(nil), sig) != nil {
+		return errCallbackSignatureInvalid
+	}
+	return nil
+}
+
+// ParsePublicKey parse the PEM encoded PKIX or PKCS1 RSA public key.
+func ParsePublicKey(b []byte) (*rsa.PublicKey, error) {
+	p, _ := pem.Decode(b)
+	if p == nil {
+		return nil, errCallbackPublicKeyInvalid
+	}
+	if k, err := x509.ParsePKIXPublicKey(p.Bytes); err == nil {
+		if pub, ok := k.(*rsa.PublicKey); ok {
+			return pub, nil
+		}
+		return nil, errCallbackPublicKeyInvalid
+	}
+	return x509.ParsePKCS1PublicKey(p.Bytes)
+}
+
+// CallbackHandler is a http.Handler verifies the OSS callback signature then calls the Handler,
+// responds 400 Bad Request if the verification failed.
+//
+// The request body is replaced so the Handler can read it again,
+// responds 200 OK with an empty body if the Handler is nil.
+type CallbackHandler struct {
+	Handler http.Handler
+	// PublicKey returns the public key given the URL of the x-oss-pub-key-url header,
+	// the default fetches the key with a timeout if the URL has one of the CallbackPublicKeyURLPrefixes,
+	// and caches it by the URL.
+	PublicKey func(url string) (*rsa.PublicKey, error)
+
+	keys sync.Map
+}
+
+func (h *CallbackHandler) publicKey(u string) (*rsa.PublicKey, error) {
+	if h.PublicKey != nil {
+		return h.PublicKey(u)
+	}
+	if k, ok := h.keys.Load(u); ok {
+		return k.(*rsa.PublicKey), nil
+	}
+	trusted := false
+	for _, i := range CallbackPublicKeyURLPrefixes {
+		if strings.HasPrefix(u, i) {
+			trusted = true
+			break
+		}
+	}
+	if !trusted {
+		return nil, errCallbackPublicKeyUntrusted
+	}
+	res, err := callbackPublicKeyClient.Get(u)
+	if err != nil {
+		return nil, err
+	}
+	var b []byte
+	if err = ReadBody(res, &b); err != nil {
+		return nil, err
+	}
+	k, err := ParsePublicKey(b)
+	if err != nil {
+		return nil, err
+	}
+	h.keys.Store(u, k)
+	return k, nil
+}
+
+func (h *CallbackHandler) verify(r *http.Request) error {
+	u, err := base64.StdEncoding.DecodeString(r.Header.Get(HeaderCallbackPubKey))
+	if err != nil {
+		return errCallbackPublicKeyInvalid
+	}
+	pub, err := h.publicKey(string(u))
+	if err != nil {
+		return err
+	}
+	body, err := ioutil.ReadAll(r.Body)
+	r.Body.Close()
+	if err != nil {
+		return err
+	}
+	r.Body = ioutil.NopCloser(bytes.NewReader(body))
+	return VerifyCallback(r, body, pub)
+}
+
+// ServeHTTP implements the http.Handler.
+func (h *CallbackHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := h.verify(r); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if h.Handler == nil {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	h.Handler.ServeHTTP(w, r)
+}
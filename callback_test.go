@@ -0,0 +1,108 @@
+// Copyright 2015 Chen Xianren. All rights reserved.
+
+package oss
+
+import (
+	"crypto"
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCallbackHandler(t *testing.T) {
+	priv, err := rsa.GenerateKey(rand.Reader, 1024)
+	fatal(t, err)
+	der, err := x509.MarshalPKIXPublicKey(&priv.PublicKey)
+	fatal(t, err)
+	pub, err := ParsePublicKey(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+	fatal(t, err)
+
+	const keyURL = "http://gosspublic.alicdn.com/callback_pub_key_v1.pem"
+
+	h := &CallbackHandler{
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			b, err := ioutil.ReadAll(r.Body)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			w.Write(append([]byte(`{"received":"`), append(b, '"', '}')...))
+		}),
+		PublicKey: func(u string) (*rsa.PublicKey, error) {
+			equal(t, "public key url", keyURL, u)
+			return pub, nil
+		},
+	}
+	s := httptest.NewServer(h)
+	defer s.Close()
+
+	post := func(path, body string, sign bool) *http.Response {
+		req, err := http.NewRequest("POST", s.URL+path, strings.NewReader(body))
+		fatal(t, err)
+		req.Header.Set(HeaderCallbackPubKey, base64.StdEncoding.EncodeToString([]byte(keyURL)))
+		if sign {
+			decoded := strings.Replace(path, "%20", " ", -1)
+			sum := md5.Sum([]byte(decoded + "\n" + body))
+			sig, err := rsa.SignPKCS1v15(rand.Reader, priv, crypto.MD5, sum[:])
+			fatal(t, err)
+			req.Header.Set("Authorization", base64.StdEncoding.EncodeToString(sig))
+		}
+		res, err := http.DefaultClient.Do(req)
+		fatal(t, err)
+		return res
+	}
+
+	res := post("/call%20back?uid=1", "bucket=oss-example", true)
+	var b []byte
+	fatal(t, ReadBody(res, &b))
+	equal(t, "callback response", `{"received":"bucket=oss-example"}`, string(b))
+
+	res = post("/callback", "bucket=oss-example", false)
+	res.Body.Close()
+	equal(t, "status code", http.StatusBadRequest, res.StatusCode)
+
+	res = post("/callback?uid=2", "bucket=oss-example", true)
+	res.Body.Close()
+	equal(t, "status code", http.StatusOK, res.StatusCode)
+
+	h.Handler = nil
+	res = post("/callback?uid=3", "bucket=oss-example", true)
+	fatal(t, ReadBody(res, &b))
+	equal(t, "nil handler response", "", string(b))
+
+	_, err = new(CallbackHandler).publicKey(s.URL + "/callback_pub_key_v1.pem")
+	equal(t, "untrusted public key url", errCallbackPublicKeyUntrusted, err)
+}
+
+func TestObjectPutCallback(t *testing.T) {
+	o := newObject()
+
+	fatal(t, o.Bucket.Put())
+
+	cb := Callback{
+		URL:  "http://127.0.0.1:1/callback",
+		Body: "bucket=${bucket}&object=${object}&uid=${x:uid}",
+		Var:  map[string]string{"x:uid": "1"},
+	}
+
+	_, err := o.PutCallback([]byte(HelloWorld), cb)
+	equal(t, "PutCallback", "CallbackFailed", ErrorCode(err))
+
+	var v []byte
+	fatal(t, o.Get(&v))
+	if HelloWorld != string(v) {
+		t.Fatal("expected HelloWorld")
+	}
+
+	fatal(t, o.Delete())
+
+	fatal(t, o.Bucket.Delete())
+}
@@ -0,0 +1,644 @@
+// Copyright 2015 Chen Xianren. All rights reserved.
+
+package main
+
+import (
+	"encoding/xml"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cxr29/aliyun-oss-go-sdk"
+)
+
+// copyMaxSize is the max size of the Copy, the larger objects are copied by the MultipartCopy.
+const copyMaxSize = 1 << 30
+
+// newFlagSet returns a silent flag set, the parse error is reported as errUsage by parseFlags.
+func newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.Usage = func() {}
+	fs.SetOutput(ioutil.Discard)
+	return fs
+}
+
+// parseFlags parse the flags and returns the arguments,
+// returns errUsage if the number of the arguments is not in [min, max], max < 0 means no limit.
+func parseFlags(fs *flag.FlagSet, args []string, min, max int) ([]string, error) {
+	if err := fs.Parse(args); err != nil {
+		return nil, errUsage
+	}
+	args = fs.Args()
+	if len(args) < min || (max >= 0 && len(args) > max) {
+		return nil, errUsage
+	}
+	return args, nil
+}
+
+func mustPath(s string) (ossPath, error) {
+	p, ok := parsePath(s)
+	if !ok {
+		return p, fmt.Errorf("invalid OSS path %q", s)
+	}
+	return p, nil
+}
+
+func mustObject(s string) (ossPath, error) {
+	p, err := mustPath(s)
+	if err == nil && p.isDir() {
+		err = fmt.Errorf("object key required %q", s)
+	}
+	return p, err
+}
+
+// list calls the fn with the decoded keys of the objects and the common prefixes of the prefix.
+func list(b oss.Bucket, prefix, delimiter string, fn func(key, size, lastModified string, dir bool) error) error {
+	it := b.NewObjectIterator(nil, oss.Params{
+		"prefix":    {prefix},
+		"delimiter": {delimiter},
+		"max-keys":  {"1000"},
+	})
+	for it.Next() {
+		r := it.Result()
+		for _, i := range r.CommonPrefixes {
+			if err := fn(i.Prefix, "", "", true); err != nil {
+				return err
+			}
+		}
+		for _, i := range r.Contents {
+			if err := fn(i.Key, i.Size, i.LastModified, false); err != nil {
+				return err
+			}
+		}
+	}
+	return it.Err()
+}
+
+func ls(s oss.Service, args []string) error {
+	fs := newFlagSet("ls")
+	recursive := fs.Bool("r", false, "")
+	args, err := parseFlags(fs, args, 0, 1)
+	if err != nil {
+		return err
+	}
+
+	if len(args) == 0 {
+		query := oss.Params{}
+		for {
+			r, err := s.ListBucket(nil, query)
+			if err != nil {
+				return err
+			}
+			for _, i := range r.Buckets.Bucket {
+				fmt.Printf("%s  %-20s  %s\n", i.CreationDate, i.Location, scheme+i.Name)
+			}
+			if !r.IsTruncated {
+				return nil
+			}
+			query.Set("marker", r.NextMarker)
+		}
+	}
+
+	p, err := mustPath(args[0])
+	if err != nil {
+		return err
+	}
+	delimiter := "/"
+	if *recursive {
+		delimiter = ""
+	}
+	return list(p.bucket(s), p.Key, delimiter, func(key, size, lastModified string, dir bool) error {
+		if dir {
+			fmt.Printf("%24s  %12s  %s\n", "", "DIR", ossPath{p.Bucket, key})
+		} else {
+			fmt.Printf("%24s  %12s  %s\n", lastModified, size, ossPath{p.Bucket, key})
+		}
+		return nil
+	})
+}
+
+func mb(s oss.Service, args []string) error {
+	fs := newFlagSet("mb")
+	acl := fs.String("acl", "", "")
+	location := fs.String("location", "", "")
+	class := fs.String("storage-class", "", "")
+	args, err := parseFlags(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+	p, err := mustPath(args[0])
+	if err != nil {
+		return err
+	}
+	b := p.bucket(s)
+	b.ACL, b.Location, b.StorageClass = *acl, *location, *class
+	return b.Put()
+}
+
+func rb(s oss.Service, args []string) error {
+	fs := newFlagSet("rb")
+	force := fs.Bool("f", false, "")
+	args, err := parseFlags(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+	p, err := mustPath(args[0])
+	if err != nil {
+		return err
+	}
+	b := p.bucket(s)
+	if *force {
+		var keys []string
+		err = list(b, "", "", func(key, _, _ string, _ bool) error {
+			keys = append(keys, key)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		if err = b.BulkDelete(keys, 4).Err(); err != nil {
+			return err
+		}
+	}
+	return b.Delete()
+}
+
+// copier copies the files and the objects, and removes the sources if move.
+type copier struct {
+	s         oss.Service
+	acl       string
+	partSize  int64
+	routines  int
+	recursive bool
+	move      bool
+}
+
+func newCopier(s oss.Service, name string, args []string, move bool) (*copier, []string, error) {
+	fs := newFlagSet(name)
+	recursive := fs.Bool("r", false, "")
+	acl := fs.String("acl", "", "")
+	partSize := fs.Int64("part-size", oss.MultipartUploadPartSize, "")
+	routines := fs.Int("routines", 4, "")
+	args, err := parseFlags(fs, args, 2, 2)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &copier{s, *acl, *partSize, *routines, *recursive, move}, args, nil
+}
+
+func (c *copier) run(src, dst string) error {
+	sp, sok := parsePath(src)
+	dp, dok := parsePath(dst)
+	switch {
+	case !sok && !dok:
+		return fmt.Errorf("source or target must be an OSS path")
+	case !sok && strings.HasPrefix(src, scheme):
+		return fmt.Errorf("invalid OSS path %q", src)
+	case !dok && strings.HasPrefix(dst, scheme):
+		return fmt.Errorf("invalid OSS path %q", dst)
+	case !sok:
+		if c.recursive {
+			return c.uploadDir(src, dp)
+		}
+		if dp.isDir() {
+			dp = dp.join(filepath.Base(src))
+		}
+		return c.upload(src, dp)
+	case c.recursive:
+		prefix := sp.Key
+		if prefix != "" && !strings.HasSuffix(prefix, "/") {
+			prefix += "/"
+		}
+		return list(sp.bucket(c.s), prefix, "", func(key, _, _ string, _ bool) error {
+			rel := strings.TrimPrefix(key, prefix)
+			if strings.HasSuffix(rel, "/") {
+				return nil
+			}
+			if dok {
+				return c.copyObject(ossPath{sp.Bucket, key}, dp.join(rel))
+			}
+			return c.download(ossPath{sp.Bucket, key}, filepath.Join(dst, filepath.FromSlash(rel)))
+		})
+	case sp.isDir():
+		return fmt.Errorf("object key required %q, use -r to copy a prefix", src)
+	case dok:
+		if dp.isDir() {
+			dp = dp.join(sp.base())
+		}
+		return c.copyObject(sp, dp)
+	default:
+		if fi, err := os.Stat(dst); (err == nil && fi.IsDir()) || strings.HasSuffix(dst, string(filepath.Separator)) {
+			dst = filepath.Join(dst, sp.base())
+		}
+		return c.download(sp, dst)
+	}
+}
+
+func (c *copier) upload(src string, dst ossPath) error {
+	f, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	o := dst.object(c.s)
+	o.ACL = c.acl
+	if _, err = o.UploadFile(f, c.partSize, c.routines); err != nil {
+		return err
+	}
+	fmt.Println("upload:", src, "to", dst)
+
+	if c.move {
+		f.Close()
+		return os.Remove(src)
+	}
+	return nil
+}
+
+func (c *copier) uploadDir(src string, dst ossPath) error {
+	header := oss.Params{}
+	if c.acl != "" {
+		header.Set("x-oss-object-acl", c.acl)
+	}
+	r, err := dst.bucket(c.s).Sync(src, dst.Key, oss.SyncOptions{
+		PartSize: c.partSize,
+		Routines: c.routines,
+		Output:   os.Stdout,
+	}, header)
+	if err != nil || !c.move {
+		return err
+	}
+
+	// only remove the files which are in the bucket now,
+	// the non-regular files such as the symlinks are not uploaded by the Sync
+	prefix := dst.Key
+	if prefix != "" && !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+	dirs := map[string]bool{}
+	for _, key := range append(r.Uploaded, r.Skipped...) {
+		name := filepath.Join(src, filepath.FromSlash(strings.TrimPrefix(key, prefix)))
+		if err = os.Remove(name); err != nil {
+			return err
+		}
+		for d := filepath.Dir(name); d != filepath.Clean(src) && !dirs[d]; d = filepath.Dir(d) {
+			dirs[d] = true
+		}
+	}
+	// remove the directories emptied by the above, the deepest first
+	var names []string
+	for d := range dirs {
+		names = append(names, d)
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(names)))
+	for _, d := range names {
+		os.Remove(d) // fails if not empty
+	}
+	return nil
+}
+
+func (c *copier) download(src ossPath, dst string) error {
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+	f, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	err = src.object(c.s).Get(f)
+	if e := f.Close(); err == nil {
+		err = e
+	}
+	if err != nil {
+		os.Remove(dst)
+		return err
+	}
+	fmt.Println("download:", src, "to", dst)
+
+	if c.move {
+		return src.object(c.s).Delete()
+	}
+	return nil
+}
+
+func (c *copier) copyObject(src, dst ossPath) error {
+	s, o := src.object(c.s), dst.object(c.s)
+	o.ACL = c.acl
+
+	h, err := s.Head()
+	if err != nil {
+		return err
+	}
+	size, err := strconv.ParseInt(h.Get("Content-Length"), 10, 64)
+	if err != nil {
+		return err
+	}
+	if size > copyMaxSize {
+		_, err = o.MultipartCopy(s, c.partSize, c.routines)
+	} else {
+		_, err = o.Copy(s)
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Println("copy:", src, "to", dst)
+
+	if c.move && src != dst {
+		return s.Delete()
+	}
+	return nil
+}
+
+func cp(s oss.Service, args []string) error {
+	c, args, err := newCopier(s, "cp", args, false)
+	if err != nil {
+		return err
+	}
+	return c.run(args[0], args[1])
+}
+
+func mv(s oss.Service, args []string) error {
+	c, args, err := newCopier(s, "mv", args, true)
+	if err != nil {
+		return err
+	}
+	return c.run(args[0], args[1])
+}
+
+func rm(s oss.Service, args []string) error {
+	fs := newFlagSet("rm")
+	recursive := fs.Bool("r", false, "")
+	args, err := parseFlags(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+	if !*recursive {
+		p, err := mustObject(args[0])
+		if err != nil {
+			return err
+		}
+		return p.object(s).Delete()
+	}
+	p, err := mustPath(args[0])
+	if err != nil {
+		return err
+	}
+	r, err := p.bucket(s).DeletePrefix(p.Key, 4)
+	if r != nil {
+		for _, k := range r.Deleted {
+			fmt.Println("delete:", ossPath{p.Bucket, k})
+		}
+		for _, i := range r.Failed {
+			fmt.Fprintln(os.Stderr, "delete failed:", ossPath{p.Bucket, i.Key}, i.Err)
+		}
+		if err == nil {
+			err = r.Err()
+		}
+	}
+	return err
+}
+
+func cat(s oss.Service, args []string) error {
+	args, err := parseFlags(newFlagSet("cat"), args, 1, 1)
+	if err != nil {
+		return err
+	}
+	p, err := mustObject(args[0])
+	if err != nil {
+		return err
+	}
+	return p.object(s).Get(os.Stdout)
+}
+
+func stat(s oss.Service, args []string) error {
+	args, err := parseFlags(newFlagSet("stat"), args, 1, 1)
+	if err != nil {
+		return err
+	}
+	p, err := mustPath(args[0])
+	if err != nil {
+		return err
+	}
+	if p.Key == "" {
+		v, err := p.bucket(s).GetInfo()
+		if err != nil {
+			return err
+		}
+		return printXML(v)
+	}
+	h, err := p.object(s).Head()
+	if err != nil {
+		return err
+	}
+	var keys []string
+	for k := range h {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fmt.Printf("%s: %s\n", k, strings.Join(h[k], ", "))
+	}
+	return nil
+}
+
+func presign(s oss.Service, args []string) error {
+	fs := newFlagSet("presign")
+	method := fs.String("method", "GET", "")
+	expires := fs.Int("expires", 3600, "")
+	args, err := parseFlags(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+	p, err := mustObject(args[0])
+	if err != nil {
+		return err
+	}
+	u, err := p.object(s).SignURL(strings.ToUpper(*method), *expires)
+	if err != nil {
+		return err
+	}
+	fmt.Println(u)
+	return nil
+}
+
+func acl(s oss.Service, args []string) error {
+	args, err := parseFlags(newFlagSet("acl"), args, 1, 2)
+	if err != nil {
+		return err
+	}
+	p, err := mustPath(args[0])
+	if err != nil {
+		return err
+	}
+	if p.Key == "" {
+		b := p.bucket(s)
+		if len(args) == 2 {
+			b.ACL = args[1]
+			return b.PutACL()
+		}
+		v, err := b.GetACL()
+		if err == nil {
+			fmt.Println(v)
+		}
+		return err
+	}
+	o := p.object(s)
+	if len(args) == 2 {
+		o.ACL = args[1]
+		return o.PutACL()
+	}
+	v, err := o.GetACL()
+	if err == nil {
+		fmt.Println(v)
+	}
+	return err
+}
+
+// config runs the get, put and delete of a bucket configuration,
+// the put reads the XML file to the new value.
+func config(s oss.Service, args []string, get func(oss.Bucket) (interface{}, error), put func(oss.Bucket, []byte) error, del func(oss.Bucket) error) error {
+	if len(args) == 0 || len(args) > 3 {
+		return errUsage
+	}
+	p, err := mustPath(args[0])
+	if err != nil {
+		return err
+	}
+	if p.Key != "" {
+		return fmt.Errorf("bucket required %q", args[0])
+	}
+	b := p.bucket(s)
+
+	action := "get"
+	if len(args) > 1 {
+		action = args[1]
+	}
+	switch {
+	case action == "get" && len(args) <= 2:
+		v, err := get(b)
+		if err != nil {
+			return err
+		}
+		return printXML(v)
+	case action == "put" && len(args) == 3:
+		data, err := ioutil.ReadFile(args[2])
+		if err != nil {
+			return err
+		}
+		return put(b, data)
+	case action == "delete" && len(args) == 2:
+		return del(b)
+	}
+	return errUsage
+}
+
+func cors(s oss.Service, args []string) error {
+	return config(s, args, func(b oss.Bucket) (interface{}, error) {
+		return b.GetCORS()
+	}, func(b oss.Bucket, data []byte) error {
+		var v oss.CORSConfiguration
+		if err := xml.Unmarshal(data, &v); err != nil {
+			return err
+		}
+		return b.PutCORS(v)
+	}, func(b oss.Bucket) error {
+		return b.DeleteCORS()
+	})
+}
+
+func lifecycle(s oss.Service, args []string) error {
+	return config(s, args, func(b oss.Bucket) (interface{}, error) {
+		return b.GetLifecycle()
+	}, func(b oss.Bucket, data []byte) error {
+		var v oss.LifecycleConfiguration
+		if err := xml.Unmarshal(data, &v); err != nil {
+			return err
+		}
+		return b.PutLifecycle(v)
+	}, func(b oss.Bucket) error {
+		return b.DeleteLifecycle()
+	})
+}
+
+func multipart(s oss.Service, args []string) error {
+	if len(args) < 2 {
+		return errUsage
+	}
+	p, err := mustPath(args[1])
+	if err != nil {
+		return err
+	}
+	b := p.bucket(s)
+
+	switch args[0] {
+	case "list":
+		if len(args) != 2 {
+			return errUsage
+		}
+		return listUploads(b, p.Key, func(key, uploadId, initiated string) error {
+			fmt.Printf("%s  %s  %s\n", initiated, uploadId, ossPath{p.Bucket, key})
+			return nil
+		})
+	case "abort":
+		if p.isDir() {
+			return fmt.Errorf("object key required %q", args[1])
+		}
+		ids := args[2:]
+		if len(ids) == 0 {
+			err = listUploads(b, p.Key, func(key, uploadId, _ string) error {
+				if key == p.Key {
+					ids = append(ids, uploadId)
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+		}
+		o := p.object(s)
+		for _, id := range ids {
+			if err = o.AbortMultipartUpload(id); err != nil {
+				return err
+			}
+			fmt.Println("abort:", id, p)
+		}
+		return nil
+	}
+	return errUsage
+}
+
+// listUploads calls the fn with the Multipart Uploads of the prefix.
+func listUploads(b oss.Bucket, prefix string, fn func(key, uploadId, initiated string) error) error {
+	query := oss.Params{}
+	query.Set("prefix", prefix)
+	for {
+		r, err := b.ListMultipartUploads(nil, query)
+		if err != nil {
+			return err
+		}
+		for _, i := range r.Upload {
+			if err = fn(i.Key, i.UploadId, i.Initiated.Format("2006-01-02T15:04:05Z")); err != nil {
+				return err
+			}
+		}
+		if !r.IsTruncated {
+			return nil
+		}
+		query.Set("key-marker", r.NextKeyMarker)
+		query.Set("upload-id-marker", r.NextUploadMarker)
+	}
+}
+
+func printXML(v interface{}) error {
+	b, err := xml.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(b))
+	return nil
+}
@@ -0,0 +1,137 @@
+// Copyright 2015 Chen Xianren. All rights reserved.
+
+// Osscli is the Aliyun OSS command-line tool built on the SDK.
+//
+// Usage:
+//
+//	osscli [-config file] command [flags] [arguments]
+//
+// The credentials are read from the config file then overridden by the environment variables
+// OSS_ACCESS_KEY_ID, OSS_ACCESS_KEY_SECRET, OSS_SECURITY_TOKEN and OSS_DOMAIN.
+//
+// The config file is JSON, the default is $HOME/.osscli.json, for example:
+//
+//	{
+//		"AccessKeyId": "YourAccessKeyId",
+//		"AccessKeySecret": "YourAccessKeySecret",
+//		"Domain": "oss-cn-hangzhou.aliyuncs.com"
+//	}
+//
+// The OSS paths are written as oss://bucket/key, run "osscli help" to see the commands.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/cxr29/aliyun-oss-go-sdk"
+)
+
+type command struct {
+	run   func(s oss.Service, args []string) error
+	usage string
+}
+
+var commands = map[string]command{
+	"ls":        {ls, "ls [-r] [oss://bucket[/prefix]]\n\tlist the buckets, or the objects and the directories, -r lists recursively"},
+	"mb":        {mb, "mb [-acl acl] [-location location] [-storage-class class] oss://bucket\n\tmake a bucket"},
+	"rb":        {rb, "rb [-f] oss://bucket\n\tremove a bucket, -f removes all the objects first"},
+	"cp":        {cp, "cp [-r] [-acl acl] [-part-size size] [-routines n] source target\n\tcopy local to OSS, OSS to local or OSS to OSS, -r copies a directory or a prefix"},
+	"mv":        {mv, "mv [-r] [-acl acl] [-part-size size] [-routines n] source target\n\tthe same as cp then remove the source, only the uploaded files of a directory"},
+	"rm":        {rm, "rm [-r] oss://bucket/key\n\tremove an object, -r removes all the objects of the prefix"},
+	"cat":       {cat, "cat oss://bucket/key\n\twrite the object content to the standard output"},
+	"stat":      {stat, "stat oss://bucket[/key]\n\tshow the bucket information or the object metadata"},
+	"presign":   {presign, "presign [-method GET] [-expires 3600] oss://bucket/key\n\tprint a signed URL"},
+	"acl":       {acl, "acl oss://bucket[/key] [acl]\n\tshow or set the bucket or the object ACL"},
+	"cors":      {cors, "cors oss://bucket [get | put file.xml | delete]\n\tshow, set or delete the CORS configuration"},
+	"lifecycle": {lifecycle, "lifecycle oss://bucket [get | put file.xml | delete]\n\tshow, set or delete the lifecycle configuration"},
+	"multipart": {multipart, "multipart list oss://bucket[/prefix]\nmultipart abort oss://bucket/key [uploadId...]\n\tlist or abort the Multipart Uploads, abort all of the key if no uploadId"},
+}
+
+var errUsage = errors.New("usage")
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: osscli [-config file] command [flags] [arguments]\n\ncommands:")
+	var names []string
+	for k := range commands {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	for _, k := range names {
+		fmt.Fprintln(os.Stderr, "\n"+commands[k].usage)
+	}
+}
+
+// loadService returns the service by the config file and the environment variables,
+// the missing default config file is ignored.
+func loadService(config string) (oss.Service, error) {
+	var s oss.Service
+
+	name := config
+	if name == "" {
+		if home, err := os.UserHomeDir(); err == nil {
+			name = filepath.Join(home, ".osscli.json")
+		}
+	}
+	if name != "" {
+		b, err := ioutil.ReadFile(name)
+		if err == nil {
+			if err = json.Unmarshal(b, &s); err != nil {
+				return s, fmt.Errorf("%s: %v", name, err)
+			}
+		} else if config != "" || !os.IsNotExist(err) {
+			return s, err
+		}
+	}
+
+	for k, v := range map[string]*string{
+		"OSS_ACCESS_KEY_ID":     &s.AccessKeyId,
+		"OSS_ACCESS_KEY_SECRET": &s.AccessKeySecret,
+		"OSS_SECURITY_TOKEN":    &s.SecurityToken,
+		"OSS_DOMAIN":            &s.Domain,
+	} {
+		if e := os.Getenv(k); e != "" {
+			*v = e
+		}
+	}
+
+	return s, nil
+}
+
+func main() {
+	config := flag.String("config", "", "the config file, default $HOME/.osscli.json")
+	flag.Usage = usage
+	flag.Parse()
+
+	args := flag.Args()
+	if len(args) == 0 || args[0] == "help" {
+		usage()
+		os.Exit(2)
+	}
+
+	c, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintln(os.Stderr, "osscli: unknown command", args[0])
+		usage()
+		os.Exit(2)
+	}
+
+	s, err := loadService(*config)
+	if err == nil {
+		err = c.run(s, args[1:])
+	}
+	if err == errUsage {
+		fmt.Fprintln(os.Stderr, "usage: osscli "+c.usage)
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "osscli:", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright 2015 Chen Xianren. All rights reserved.
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParsePath(t *testing.T) {
+	for s, expected := range map[string]ossPath{
+		"oss://bucket":         {"bucket", ""},
+		"oss://bucket/":        {"bucket", ""},
+		"oss://bucket/a/b.txt": {"bucket", "a/b.txt"},
+		"oss://bucket/a/dir/":  {"bucket", "a/dir/"},
+	} {
+		p, ok := parsePath(s)
+		if !ok || p != expected {
+			t.Fatal(s, "expected", expected, "but got", p, ok)
+		}
+	}
+	for _, s := range []string{"bucket/key", "/tmp/file", "oss://", "oss://Bad_Bucket/key"} {
+		if _, ok := parsePath(s); ok {
+			t.Fatal("expected invalid", s)
+		}
+	}
+
+	p := ossPath{"bucket", "a/dir"}
+	if s := p.join("b.txt").String(); s != "oss://bucket/a/dir/b.txt" {
+		t.Fatal("join", s)
+	}
+	if !(ossPath{"bucket", "a/"}).isDir() || (ossPath{"bucket", "a"}).isDir() {
+		t.Fatal("isDir")
+	}
+}
+
+func TestLoadService(t *testing.T) {
+	dir, err := ioutil.TempDir("", "osscli")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	name := filepath.Join(dir, "config.json")
+	err = ioutil.WriteFile(name, []byte(`{"AccessKeyId": "id", "AccessKeySecret": "secret", "Domain": "example.com"}`), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	os.Setenv("OSS_ACCESS_KEY_SECRET", "env-secret")
+	defer os.Unsetenv("OSS_ACCESS_KEY_SECRET")
+
+	s, err := loadService(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.AccessKeyId != "id" || s.AccessKeySecret != "env-secret" || s.Domain != "example.com" {
+		t.Fatal("loadService", s)
+	}
+
+	if _, err = loadService(filepath.Join(dir, "missing.json")); err == nil {
+		t.Fatal("expected the missing config file error")
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright 2015 Chen Xianren. All rights reserved.
+
+package main
+
+import (
+	"path"
+	"strings"
+
+	"github.com/cxr29/aliyun-oss-go-sdk"
+)
+
+const scheme = "oss://"
+
+// ossPath represents an OSS path, the Key is empty for a bucket.
+type ossPath struct {
+	Bucket string
+	Key    string
+}
+
+func (p ossPath) String() string {
+	return scheme + p.Bucket + "/" + p.Key
+}
+
+// isDir reports whether the key is empty or ends with "/".
+func (p ossPath) isDir() bool {
+	return p.Key == "" || strings.HasSuffix(p.Key, "/")
+}
+
+// join returns the path of the name under the directory key.
+func (p ossPath) join(name string) ossPath {
+	if p.Key != "" && !strings.HasSuffix(p.Key, "/") {
+		p.Key += "/"
+	}
+	p.Key += name
+	return p
+}
+
+func (p ossPath) base() string {
+	return path.Base(p.Key)
+}
+
+func (p ossPath) bucket(s oss.Service) oss.Bucket {
+	return s.NewBucket(p.Bucket)
+}
+
+func (p ossPath) object(s oss.Service) oss.Object {
+	return s.NewBucket(p.Bucket).NewObject(p.Key)
+}
+
+// parsePath parse the oss://bucket/key, ok is false if it is not an OSS path.
+func parsePath(s string) (p ossPath, ok bool) {
+	if !strings.HasPrefix(s, scheme) {
+		return p, false
+	}
+	s = s[len(scheme):]
+	if n := strings.Index(s, "/"); n == -1 {
+		p.Bucket = s
+	} else {
+		p.Bucket, p.Key = s[:n], s[n+1:]
+	}
+	return p, oss.IsBucketName(p.Bucket)
+}
@@ -0,0 +1,604 @@
+// Copyright 2015 Chen Xianren. All rights reserved.
+
+package oss
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"encoding/base64"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+)
+
+// Client side encryption content algorithms
+const (
+	CryptoAESCTR = "AES/CTR/NoPadding"
+	CryptoAESGCM = "AES/GCM/NoPadding"
+)
+
+// Client side encryption key wrap algorithms
+const (
+	WrapRSAOAEP = "RSA/NONE/OAEPWithSHA-1AndMGF1Padding"
+	WrapAESGCM  = "AES/GCM/NoPadding"
+)
+
+// Client side encryption headers, stored as the object user metadata.
+const (
+	HeaderCryptoKey           = "x-oss-meta-client-side-encryption-key"
+	HeaderCryptoStart         = "x-oss-meta-client-side-encryption-start"
+	HeaderCryptoCEKAlg        = "x-oss-meta-client-side-encryption-cek-alg"
+	HeaderCryptoWrapAlg       = "x-oss-meta-client-side-encryption-wrap-alg"
+	HeaderCryptoMatDesc       = "x-oss-meta-client-side-encryption-matdesc"
+	HeaderCryptoContentLength = "x-oss-meta-client-side-encryption-unencrypted-content-length"
+	HeaderCryptoDataSize      = "x-oss-meta-client-side-encryption-data-size"
+	HeaderCryptoPartSize      = "x-oss-meta-client-side-encryption-part-size"
+)
+
+var (
+	errCryptoKeysRequired   = errors.New("crypto master key provider required")
+	errCryptoKeyNotFound    = errors.New("crypto master key not found")
+	errCryptoKeyInvalid     = errors.New("crypto master key invalid")
+	errCryptoNotSupported   = errors.New("crypto algorithm not supported")
+	errCryptoNotEncrypted   = errors.New("object not client side encrypted")
+	errCryptoPartSize       = errors.New("crypto part size must be a multiple of 16")
+	errCryptoRangeInvalid   = errors.New("crypto range invalid")
+	errCryptoUploadRequired = errors.New("crypto multipart upload required")
+	errCryptoPartMismatch   = errors.New("crypto part size mismatch")
+	errCryptoDataSize       = errors.New("crypto data size must be greater than 0")
+)
+
+// MasterKey wraps and unwraps the data keys of the client side encryption.
+type MasterKey interface {
+	// MatDesc returns the material description which identifies the master key,
+	// it is stored with the object and used to find the master key when decrypt.
+	MatDesc() string
+	// Algorithm returns the key wrap algorithm.
+	Algorithm() string
+	// Wrap encrypts the data key or the IV.
+	Wrap(b []byte) ([]byte, error)
+	// Unwrap decrypts the wrapped data key or IV.
+	Unwrap(b []byte) ([]byte, error)
+}
+
+// MasterKeyProvider provides the master keys of the client side encryption.
+type MasterKeyProvider interface {
+	// EncryptionKey returns the master key to encrypt the new objects.
+	EncryptionKey() (MasterKey, error)
+	// DecryptionKey returns the master key given the material description of the object.
+	DecryptionKey(matDesc string) (MasterKey, error)
+}
+
+type masterKeys struct {
+	current MasterKey
+	keys    map[string]MasterKey
+}
+
+// NewMasterKeyProvider returns a MasterKeyProvider which encrypts with the current master key
+// and decrypts with any of the current and the other master keys by the material description.
+func NewMasterKeyProvider(current MasterKey, others ...MasterKey) MasterKeyProvider {
+	v := &masterKeys{
+		current: current,
+		keys:    make(map[string]MasterKey, len(others)+1),
+	}
+	for _, i := range others {
+		v.keys[i.MatDesc()] = i
+	}
+	if current != nil {
+		v.keys[current.MatDesc()] = current
+	}
+	return v
+}
+
+func (v *masterKeys) EncryptionKey() (MasterKey, error) {
+	if v.current == nil {
+		return nil, errCryptoKeyNotFound
+	}
+	return v.current, nil
+}
+
+func (v *masterKeys) DecryptionKey(matDesc string) (MasterKey, error) {
+	if k, ok := v.keys[matDesc]; ok {
+		return k, nil
+	}
+	return nil, errCryptoKeyNotFound
+}
+
+type rsaMasterKey struct {
+	matDesc string
+	pub     *rsa.PublicKey
+	priv    *rsa.PrivateKey
+}
+
+// NewRSAMasterKey returns a RSA-OAEP MasterKey given the material description and the keys,
+// only the public key can encrypt and only the private key can decrypt,
+// the public key is got from the private key if it is nil.
+func NewRSAMasterKey(matDesc string, pub *rsa.PublicKey, priv *rsa.PrivateKey) (MasterKey, error) {
+	if pub == nil {
+		if priv == nil {
+			return nil, errCryptoKeyInvalid
+		}
+		pub = &priv.PublicKey
+	}
+	return &rsaMasterKey{matDesc, pub, priv}, nil
+}
+
+func (k *rsaMasterKey) MatDesc() string {
+	return k.matDesc
+}
+
+func (k *rsaMasterKey) Algorithm() string {
+	return WrapRSAOAEP
+}
+
+func (k *rsaMasterKey) Wrap(b []byte) ([]byte, error) {
+	return rsa.EncryptOAEP(sha1.New(), rand.Reader, k.pub, b, nil)
+}
+
+func (k *rsaMasterKey) Unwrap(b []byte) ([]byte, error) {
+	if k.priv == nil {
+		return nil, errCryptoKeyInvalid
+	}
+	return rsa.DecryptOAEP(sha1.New(), rand.Reader, k.priv, b, nil)
+}
+
+type aesMasterKey struct {
+	matDesc string
+	aead    cipher.AEAD
+}
+
+// NewAESMasterKey returns a AES-GCM MasterKey given the material description and the key,
+// the key must be 16, 24 or 32 bytes.
+func NewAESMasterKey(matDesc string, key []byte) (MasterKey, error) {
+	c, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(c)
+	if err != nil {
+		return nil, err
+	}
+	return &aesMasterKey{matDesc, aead}, nil
+}
+
+func (k *aesMasterKey) MatDesc() string {
+	return k.matDesc
+}
+
+func (k *aesMasterKey) Algorithm() string {
+	return WrapAESGCM
+}
+
+func (k *aesMasterKey) Wrap(b []byte) ([]byte, error) {
+	nonce := make([]byte, k.aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	return k.aead.Seal(nonce, nonce, b, nil), nil
+}
+
+func (k *aesMasterKey) Unwrap(b []byte) ([]byte, error) {
+	n := k.aead.NonceSize()
+	if len(b) < n {
+		return nil, errCryptoKeyInvalid
+	}
+	return k.aead.Open(nil, b[:n], b[n:], nil)
+}
+
+// envelope is the data key and the IV of an encrypted object.
+type envelope struct {
+	alg string
+	key []byte
+	iv  []byte
+}
+
+func newEnvelope(alg string) (*envelope, error) {
+	e := &envelope{alg: alg, key: make([]byte, 32)}
+	switch alg {
+	case CryptoAESCTR:
+		e.iv = make([]byte, aes.BlockSize)
+	case CryptoAESGCM:
+		e.iv = make([]byte, 12)
+	default:
+		return nil, errCryptoNotSupported
+	}
+	if _, err := io.ReadFull(rand.Reader, e.key); err != nil {
+		return nil, err
+	}
+	if _, err := io.ReadFull(rand.Reader, e.iv); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// openEnvelope unwraps the data key and the IV from the object headers.
+func openEnvelope(h http.Header, keys MasterKeyProvider) (*envelope, error) {
+	alg := h.Get(HeaderCryptoCEKAlg)
+	if alg == "" {
+		return nil, errCryptoNotEncrypted
+	}
+	mk, err := keys.DecryptionKey(h.Get(HeaderCryptoMatDesc))
+	if err != nil {
+		return nil, err
+	}
+	if mk.Algorithm() != h.Get(HeaderCryptoWrapAlg) {
+		return nil, errCryptoNotSupported
+	}
+	e := &envelope{alg: alg}
+	for _, i := range []struct {
+		header string
+		value  *[]byte
+	}{
+		{HeaderCryptoKey, &e.key},
+		{HeaderCryptoStart, &e.iv},
+	} {
+		b, err := base64.StdEncoding.DecodeString(h.Get(i.header))
+		if err != nil {
+			return nil, err
+		}
+		if *i.value, err = mk.Unwrap(b); err != nil {
+			return nil, err
+		}
+	}
+	return e, nil
+}
+
+// setHeader wraps the data key and the IV by the master key as the object headers.
+func (e *envelope) setHeader(header Params, mk MasterKey) error {
+	key, err := mk.Wrap(e.key)
+	if err != nil {
+		return err
+	}
+	iv, err := mk.Wrap(e.iv)
+	if err != nil {
+		return err
+	}
+	header.Set(HeaderCryptoKey, base64.StdEncoding.EncodeToString(key))
+	header.Set(HeaderCryptoStart, base64.StdEncoding.EncodeToString(iv))
+	header.Set(HeaderCryptoCEKAlg, e.alg)
+	header.Set(HeaderCryptoWrapAlg, mk.Algorithm())
+	header.Set(HeaderCryptoMatDesc, mk.MatDesc())
+	return nil
+}
+
+// stream returns the AES-CTR stream starts at the offset, which must be a multiple of 16.
+func (e *envelope) stream(offset int64) (cipher.Stream, error) {
+	if e.alg != CryptoAESCTR {
+		return nil, errCryptoNotSupported
+	}
+	c, err := aes.NewCipher(e.key)
+	if err != nil {
+		return nil, err
+	}
+	iv := make([]byte, len(e.iv))
+	copy(iv, e.iv)
+	n := uint64(offset / aes.BlockSize)
+	for i := len(iv) - 1; i >= 0 && n > 0; i-- {
+		n += uint64(iv[i])
+		iv[i] = byte(n)
+		n >>= 8
+	}
+	return cipher.NewCTR(c, iv), nil
+}
+
+func (e *envelope) aead() (cipher.AEAD, error) {
+	c, err := aes.NewCipher(e.key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(c)
+}
+
+func (e *envelope) seal(b []byte) ([]byte, error) {
+	switch e.alg {
+	case CryptoAESCTR:
+		s, err := e.stream(0)
+		if err != nil {
+			return nil, err
+		}
+		c := make([]byte, len(b))
+		s.XORKeyStream(c, b)
+		return c, nil
+	case CryptoAESGCM:
+		aead, err := e.aead()
+		if err != nil {
+			return nil, err
+		}
+		return aead.Seal(nil, e.iv, b, nil), nil
+	}
+	return nil, errCryptoNotSupported
+}
+
+func (e *envelope) open(b []byte) ([]byte, error) {
+	switch e.alg {
+	case CryptoAESCTR:
+		return e.seal(b)
+	case CryptoAESGCM:
+		aead, err := e.aead()
+		if err != nil {
+			return nil, err
+		}
+		return aead.Open(nil, e.iv, b, nil)
+	}
+	return nil, errCryptoNotSupported
+}
+
+func readPutData(data interface{}) ([]byte, error) {
+	switch v := data.(type) {
+	case []byte:
+		return v, nil
+	case *[]byte:
+		return *v, nil
+	case io.Reader:
+		return ioutil.ReadAll(v)
+	}
+	return nil, errDataTypeNotSupported
+}
+
+// CryptoObject represents a client side encrypted OSS object,
+// the content is encrypted by a random data key (the envelope encryption),
+// and the data key and the IV are wrapped by the master key then stored as the user metadata.
+//
+// The Algorithm is the content encryption algorithm, AES/CTR/NoPadding if it is the empty string,
+// only the AES/CTR/NoPadding supports the range get and the multipart upload.
+//
+// The Put, Get, Range and the multipart upload methods are overwritten,
+// the other methods of the Object are not encrypted, such as Append and Copy.
+type CryptoObject struct {
+	Object
+	Keys      MasterKeyProvider
+	Algorithm string // AES/CTR/NoPadding, AES/GCM/NoPadding
+}
+
+// NewCryptoObject returns a new CryptoObject given the master key provider from the object.
+func (o Object) NewCryptoObject(keys MasterKeyProvider) CryptoObject {
+	return CryptoObject{
+		Object: o,
+		Keys:   keys,
+	}
+}
+
+func (o CryptoObject) newEnvelope(alg string, header Params) (*envelope, error) {
+	if o.Keys == nil {
+		return nil, errCryptoKeysRequired
+	}
+	mk, err := o.Keys.EncryptionKey()
+	if err != nil {
+		return nil, err
+	}
+	e, err := newEnvelope(alg)
+	if err != nil {
+		return nil, err
+	}
+	if err = e.setHeader(header, mk); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+func (o CryptoObject) openEnvelope(h http.Header) (*envelope, error) {
+	if o.Keys == nil {
+		return nil, errCryptoKeysRequired
+	}
+	return openEnvelope(h, o.Keys)
+}
+
+// Put encrypts the data as the object content, returns the ETag.
+//
+// The first optional Params is for Header, the second is for Query.
+//
+// The data's type must be
+// []byte, *[]byte, *os.File, *bytes.Buffer, *bytes.Reader or *strings.Reader,
+// and it is read into the memory, use the multipart upload for the large data.
+func (o CryptoObject) Put(data interface{}, args ...Params) (string, error) {
+	if !isPutDataType(data) {
+		return "", errDataTypeNotSupported
+	}
+
+	b, err := readPutData(data)
+	if err != nil {
+		return "", err
+	}
+
+	alg := o.Algorithm
+	if alg == "" {
+		alg = CryptoAESCTR
+	}
+
+	header, query := getHeaderQuery(args)
+	e, err := o.newEnvelope(alg, header)
+	if err != nil {
+		return "", err
+	}
+	header.Set(HeaderCryptoContentLength, strconv.Itoa(len(b)))
+
+	c, err := e.seal(b)
+	if err != nil {
+		return "", err
+	}
+
+	return o.Object.Put(c, header, query)
+}
+
+// Get decrypts the object content to the data.
+//
+// The first optional Params is for Header, the second is for Query.
+//
+// The data's type must be
+// *[]byte, *os.File, *bytes.Buffer
+func (o CryptoObject) Get(data interface{}, args ...Params) error {
+	if !isGetDataType(data) {
+		return errDataTypeNotSupported
+	}
+
+	header, query := getHeaderQuery(args)
+	o.setVersionId(query)
+
+	res, err := o.GetResponse("GET", nil, header, query)
+	if err != nil {
+		return err
+	}
+
+	var b []byte
+	if err = ReadBody(res, &b); err != nil {
+		return err
+	}
+
+	e, err := o.openEnvelope(res.Header)
+	if err != nil {
+		return err
+	}
+
+	p, err := e.open(b)
+	if err != nil {
+		return err
+	}
+
+	_, err = readBody(bytes.NewReader(p), data)
+	return err
+}
+
+// Range decrypts the object range content to the data given the first-byte-pos and the length,
+// returns the range-length and the instance-length.
+//
+// The first-byte-pos must be gte 0 and only the AES/CTR/NoPadding encrypted object is supported,
+// the range is aligned to the AES block size when get, see the method Object.Range to get more.
+func (o CryptoObject) Range(first, length int64, data interface{}, args ...Params) (int64, int64, error) {
+	if !isGetDataType(data) {
+		return 0, 0, errDataTypeNotSupported
+	}
+	if first < 0 {
+		return 0, 0, errCryptoRangeInvalid
+	}
+
+	skip := first % aes.BlockSize
+	if length > 0 {
+		length += skip
+	}
+
+	var b []byte
+	l, t, h, err := o.Object.getRange(first-skip, length, &b, args...)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	e, err := o.openEnvelope(h)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	s, err := e.stream(first - skip)
+	if err != nil {
+		return 0, 0, err
+	}
+	s.XORKeyStream(b, b)
+
+	if _, err = readBody(bytes.NewReader(b[skip:]), data); err != nil {
+		return 0, 0, err
+	}
+
+	return l - skip, t, nil
+}
+
+// CryptoMultipartUpload represents an initialized client side encrypted Multipart Upload.
+type CryptoMultipartUpload struct {
+	UploadId string
+	DataSize int64
+	PartSize int64
+	envelope *envelope
+}
+
+// checkPart checks the data size of the part,
+// it must be the PartSize except the last part which is the rest of the DataSize.
+func (mu *CryptoMultipartUpload) checkPart(partNumber int, size int64) error {
+	if mu.DataSize <= 0 {
+		return errCryptoDataSize
+	}
+	n := (mu.DataSize + mu.PartSize - 1) / mu.PartSize
+	want := mu.PartSize
+	if int64(partNumber) == n {
+		want = mu.DataSize - (n-1)*mu.PartSize
+	}
+	if int64(partNumber) > n || size != want {
+		return errCryptoPartMismatch
+	}
+	return nil
+}
+
+// InitiateMultipartUpload initialize a client side encrypted Multipart Upload event
+// given the total data size, which must be greater than 0, and the part size, which must be a multiple of 16,
+// all the parts except the last one must be the part size, so the part offsets are known when encrypting.
+//
+// The first optional Params is for Header, the second is for Query.
+//
+// The AES/CTR/NoPadding is always used,
+// complete or abort it by the methods of the Object with the UploadId.
+func (o CryptoObject) InitiateMultipartUpload(dataSize, partSize int64, args ...Params) (*CryptoMultipartUpload, error) {
+	if dataSize <= 0 {
+		return nil, errCryptoDataSize
+	}
+	if partSize <= 0 || partSize%aes.BlockSize != 0 {
+		return nil, errCryptoPartSize
+	}
+
+	header, query := getHeaderQuery(args)
+	e, err := o.newEnvelope(CryptoAESCTR, header)
+	if err != nil {
+		return nil, err
+	}
+	header.Set(HeaderCryptoDataSize, strconv.FormatInt(dataSize, 10))
+	header.Set(HeaderCryptoPartSize, strconv.FormatInt(partSize, 10))
+
+	imu, err := o.Object.InitiateMultipartUpload(header, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CryptoMultipartUpload{
+		UploadId: imu.UploadId,
+		DataSize: dataSize,
+		PartSize: partSize,
+		envelope: e,
+	}, nil
+}
+
+// UploadPart encrypts the data as a part given a partNumber and the encrypted Multipart Upload,
+// returns the ETag. The data must be the PartSize except the last part which is the rest of the DataSize.
+//
+// The first optional Params is for Header, the second is for Query.
+//
+// See the method Object.UploadPart to get more.
+func (o CryptoObject) UploadPart(mu *CryptoMultipartUpload, partNumber int, data interface{}, args ...Params) (string, error) {
+	if mu == nil || mu.envelope == nil {
+		return "", errCryptoUploadRequired
+	}
+	if !(partNumber >= 1 && partNumber <= 10000) {
+		return "", errPartNumberInvalid
+	}
+	if !isPutDataType(data) {
+		return "", errDataTypeNotSupported
+	}
+
+	b, err := readPutData(data)
+	if err != nil {
+		return "", err
+	}
+	if err = mu.checkPart(partNumber, int64(len(b))); err != nil {
+		return "", err
+	}
+
+	s, err := mu.envelope.stream(int64(partNumber-1) * mu.PartSize)
+	if err != nil {
+		return "", err
+	}
+	c := make([]byte, len(b))
+	s.XORKeyStream(c, b)
+
+	return o.Object.UploadPart(partNumber, mu.UploadId, c, args...)
+}
@@ -0,0 +1,173 @@
+// Copyright 2015 Chen Xianren. All rights reserved.
+
+package oss
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"net/http"
+	"testing"
+)
+
+func newTestMasterKeys(t *testing.T) MasterKeyProvider {
+	priv, err := rsa.GenerateKey(rand.Reader, 2048)
+	fatal(t, err)
+	rk, err := NewRSAMasterKey("rsa", nil, priv)
+	fatal(t, err)
+	ak, err := NewAESMasterKey("aes", bytes.Repeat([]byte{1}, 32))
+	fatal(t, err)
+	return NewMasterKeyProvider(rk, ak)
+}
+
+func canonicalHeader(header Params) http.Header {
+	h := http.Header{}
+	for k, v := range header {
+		h[http.CanonicalHeaderKey(k)] = v
+	}
+	return h
+}
+
+func TestCryptoEnvelope(t *testing.T) {
+	keys := newTestMasterKeys(t)
+	mk, err := keys.EncryptionKey()
+	fatal(t, err)
+
+	data := bytes.Repeat([]byte(HelloWorld), 10)
+
+	for _, alg := range []string{CryptoAESCTR, CryptoAESGCM} {
+		e, err := newEnvelope(alg)
+		fatal(t, err)
+
+		header := Params{}
+		fatal(t, e.setHeader(header, mk))
+
+		c, err := e.seal(data)
+		fatal(t, err)
+		if bytes.Equal(c[:len(data)], data) {
+			t.Fatal("expected encrypted")
+		}
+
+		x, err := openEnvelope(canonicalHeader(header), keys)
+		fatal(t, err)
+		p, err := x.open(c)
+		fatal(t, err)
+		if !bytes.Equal(data, p) {
+			t.Fatal("expected Equal", alg)
+		}
+	}
+
+	e, err := newEnvelope(CryptoAESCTR)
+	fatal(t, err)
+	e.iv = bytes.Repeat([]byte{0xff}, 16) // counter overflow
+	c, err := e.seal(data)
+	fatal(t, err)
+	for _, offset := range []int64{16, 32, 256} {
+		s, err := e.stream(offset)
+		fatal(t, err)
+		p := make([]byte, len(c)-int(offset))
+		s.XORKeyStream(p, c[offset:])
+		if !bytes.Equal(data[offset:], p) {
+			t.Fatal("expected Equal at offset", offset)
+		}
+	}
+
+	ak, err := NewAESMasterKey("aes", bytes.Repeat([]byte{2}, 32))
+	fatal(t, err)
+	header := Params{}
+	fatal(t, e.setHeader(header, ak))
+	if _, err = openEnvelope(canonicalHeader(header), keys); err == nil {
+		t.Fatal("expected error")
+	}
+
+	if _, err = openEnvelope(http.Header{}, keys); err != errCryptoNotEncrypted {
+		t.Fatal("expected", errCryptoNotEncrypted)
+	}
+}
+
+func TestCryptoMultipartUploadCheckPart(t *testing.T) {
+	mu := &CryptoMultipartUpload{PartSize: 32}
+	equal(t, "unknown data size", errCryptoDataSize, mu.checkPart(1, 32))
+
+	mu.DataSize = 70
+	equal(t, "first", nil, mu.checkPart(1, 32))
+	equal(t, "large part", errCryptoPartMismatch, mu.checkPart(1, 33))
+	equal(t, "last", nil, mu.checkPart(3, 6))
+	equal(t, "short non-last", errCryptoPartMismatch, mu.checkPart(2, 16))
+	equal(t, "wrong last", errCryptoPartMismatch, mu.checkPart(3, 32))
+	equal(t, "extra", errCryptoPartMismatch, mu.checkPart(4, 1))
+}
+
+func TestCryptoInitiateMultipartUploadDataSize(t *testing.T) {
+	o := Object{}.NewCryptoObject(nil)
+	_, err := o.InitiateMultipartUpload(0, 32)
+	equal(t, "data size", errCryptoDataSize, err)
+}
+
+func TestCryptoObject(t *testing.T) {
+	o := newObject().NewCryptoObject(newTestMasterKeys(t))
+
+	fatal(t, o.Bucket.Put())
+
+	data := bytes.Repeat([]byte(HelloWorld), 10)
+
+	_, err := o.Put(data)
+	fatal(t, err)
+
+	var v []byte
+	fatal(t, o.Object.Get(&v))
+	if bytes.Equal(data, v) {
+		t.Fatal("expected encrypted")
+	}
+
+	fatal(t, o.Get(&v))
+	if !bytes.Equal(data, v) {
+		t.Fatal("expected Equal")
+	}
+
+	var b []byte
+	l, il, err := o.Range(21, 100, &b)
+	fatal(t, err)
+	equal(t, "range length", int64(100), l)
+	equal(t, "instance length", int64(len(data)), il)
+	if !bytes.Equal(data[21:121], b) {
+		t.Fatal("expected Equal")
+	}
+
+	o.Algorithm = CryptoAESGCM
+	_, err = o.Put(data)
+	fatal(t, err)
+	fatal(t, o.Get(&v))
+	if !bytes.Equal(data, v) {
+		t.Fatal("expected Equal")
+	}
+
+	fatal(t, o.Delete())
+
+	const n = 100 * 1024
+	part := make([]byte, n+10)
+	copy(part, HelloWorld)
+
+	mu, err := o.InitiateMultipartUpload(int64(len(part)), n)
+	fatal(t, err)
+
+	cmu := CompleteMultipartUpload{}
+	etag, err := o.UploadPart(mu, 1, part[:n])
+	fatal(t, err)
+	cmu.Part = append(cmu.Part, CompleteMultipartUploadPart{1, etag})
+	etag, err = o.UploadPart(mu, 2, part[n:])
+	fatal(t, err)
+	cmu.Part = append(cmu.Part, CompleteMultipartUploadPart{2, etag})
+
+	_, err = o.CompleteMultipartUpload(mu.UploadId, cmu)
+	fatal(t, err)
+
+	fatal(t, o.Get(&v))
+	if !bytes.Equal(part, v) {
+		t.Fatal("expected Equal")
+	}
+
+	fatal(t, o.Delete())
+
+	fatal(t, o.Bucket.Delete())
+}
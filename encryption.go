@@ -0,0 +1,115 @@
+// Copyright 2015 Chen Xianren. All rights reserved.
+
+package oss
+
+// OSS Server Side Encryption Algorithm List
+const (
+	SSEAES256 = "AES256"
+	SSEKMS    = "KMS"
+)
+
+// Server side encryption headers
+const (
+	HeaderServerSideEncryption      = "x-oss-server-side-encryption"
+	HeaderServerSideEncryptionKeyId = "x-oss-server-side-encryption-key-id"
+)
+
+// ServerSideEncryption represents the server side encryption of an object.
+//
+// The KeyId is the KMS master key id, only used with the KMS algorithm,
+// the default KMS key is used if it is the empty string.
+type ServerSideEncryption struct {
+	Algorithm string // AES256, KMS
+	KeyId     string
+}
+
+func (v ServerSideEncryption) setHeader(header Params) {
+	if v.Algorithm != "" {
+		header.Set(HeaderServerSideEncryption, v.Algorithm)
+		if v.KeyId != "" {
+			header.Set(HeaderServerSideEncryptionKeyId, v.KeyId)
+		}
+	}
+}
+
+// GetEncryption returns the object server side encryption by the method Head,
+// the Algorithm is the empty string if the object is not encrypted.
+//
+// The first optional Params is for Header, the second is for Query.
+//
+// Get and record:
+//
+//	o.Encryption, err = o.GetEncryption()
+//
+// Relevant documentation:
+//
+// https://docs.aliyun.com/#/pub/oss/api-reference/object&HeadObject
+func (o Object) GetEncryption(args ...Params) (ServerSideEncryption, error) {
+	h, err := o.Head(args...)
+	if err != nil {
+		return ServerSideEncryption{}, err
+	}
+	return ServerSideEncryption{
+		Algorithm: h.Get(HeaderServerSideEncryption),
+		KeyId:     h.Get(HeaderServerSideEncryptionKeyId),
+	}, nil
+}
+
+// PutEncryption set the default server side encryption rule of the bucket.
+//
+// The first optional Params is for Header, the second is for Query.
+//
+// Relevant documentation:
+//
+// https://help.aliyun.com/document_detail/117914.html
+func (b Bucket) PutEncryption(rule ServerSideEncryptionRule, args ...Params) error {
+	header, query := getHeaderQuery(args)
+	query.Set("encryption", "")
+	return b.Do("PUT", "", rule, nil, header, query)
+}
+
+// GetEncryption returns the default server side encryption rule of the bucket.
+//
+// The first optional Params is for Header, the second is for Query.
+//
+// Relevant documentation:
+//
+// https://help.aliyun.com/document_detail/117915.html
+func (b Bucket) GetEncryption(args ...Params) (*ServerSideEncryptionRule, error) {
+	header, query := getHeaderQuery(args)
+	query.Set("encryption", "")
+
+	v := new(ServerSideEncryptionRule)
+
+	err := b.Do("GET", "", nil, v, header, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+// DeleteEncryption remove the default server side encryption rule of the bucket.
+//
+// The first optional Params is for Header, the second is for Query.
+//
+// Relevant documentation:
+//
+// https://help.aliyun.com/document_detail/117916.html
+func (b Bucket) DeleteEncryption(args ...Params) error {
+	header, query := getHeaderQuery(args)
+	query.Set("encryption", "")
+	return b.Do("DELETE", "", nil, nil, header, query)
+}
+
+// ServerSideEncryptionRule represents the default server side encryption rule of the bucket.
+//
+// Relevant documentation:
+//
+// https://help.aliyun.com/document_detail/117914.html
+type ServerSideEncryptionRule struct {
+	ApplyServerSideEncryptionByDefault struct {
+		SSEAlgorithm   string // AES256, KMS
+		KMSMasterKeyID string `xml:",omitempty"`
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright 2015 Chen Xianren. All rights reserved.
+
+package oss
+
+import (
+	"testing"
+)
+
+func TestEncryption(t *testing.T) {
+	o := newObject()
+
+	fatal(t, o.Bucket.Put())
+
+	o.Encryption.Algorithm = SSEAES256
+	_, err := o.Put([]byte(HelloWorld))
+	fatal(t, err)
+
+	o.Encryption = ServerSideEncryption{}
+	o.Encryption, err = o.GetEncryption()
+	fatal(t, err)
+	equal(t, "Algorithm", SSEAES256, o.Encryption.Algorithm)
+
+	var v []byte
+	fatal(t, o.Get(&v))
+	if HelloWorld != string(v) {
+		t.Fatal("expected HelloWorld")
+	}
+
+	fatal(t, o.Delete())
+
+	rule := ServerSideEncryptionRule{}
+	rule.ApplyServerSideEncryptionByDefault.SSEAlgorithm = SSEAES256
+	fatal(t, o.Bucket.PutEncryption(rule))
+
+	r, err := o.Bucket.GetEncryption()
+	fatal(t, err)
+	equal(t, "SSEAlgorithm", SSEAES256, r.ApplyServerSideEncryptionByDefault.SSEAlgorithm)
+
+	o.Encryption = ServerSideEncryption{}
+	_, err = o.Put([]byte(HelloWorld))
+	fatal(t, err)
+
+	o.Encryption, err = o.GetEncryption()
+	fatal(t, err)
+	equal(t, "Algorithm", SSEAES256, o.Encryption.Algorithm)
+
+	fatal(t, o.Bucket.DeleteEncryption())
+
+	_, err = o.Bucket.GetEncryption()
+	e, ok := err.(Error)
+	if !(ok && e.Code == "NoSuchServerSideEncryptionRule") {
+		t.Fatal("expected NoSuchServerSideEncryptionRule")
+	}
+
+	fatal(t, o.Delete())
+
+	fatal(t, o.Bucket.Delete())
+}
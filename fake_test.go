@@ -0,0 +1,227 @@
+// Copyright 2015 Chen Xianren. All rights reserved.
+
+package oss
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeOSS is an in-memory OSS bucket server for the offline tests,
+// supports the list by pages, head, get with range, put and delete of the objects and the Multipart Upload.
+type fakeOSS struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+	modTime time.Time
+	gets    int                       // the number of the object GET requests
+	uploads map[string]map[int][]byte // the parts of the Multipart Uploads by the uploadId
+	nextId  int
+	before  func(w http.ResponseWriter, r *http.Request) bool // called first if not nil, returns true if served
+}
+
+// newFakeOSS starts the fake server and routes all the requests of the http.DefaultClient to it,
+// returns the bucket of the fake server.
+func newFakeOSS(t *testing.T) (*fakeOSS, Bucket) {
+	f := &fakeOSS{
+		objects: map[string][]byte{},
+		uploads: map[string]map[int][]byte{},
+		modTime: time.Date(2015, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+	s := httptest.NewServer(f)
+	transport := http.DefaultClient.Transport
+	http.DefaultClient.Transport = &http.Transport{
+		DialContext: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			return new(net.Dialer).DialContext(ctx, network, s.Listener.Addr().String())
+		},
+	}
+	t.Cleanup(func() {
+		http.DefaultClient.Transport = transport
+		s.Close()
+	})
+	return f, Bucket{
+		Service: Service{Unsafe: true, Domain: "oss.test", AccessKeyId: "id", AccessKeySecret: "secret"},
+		Name:    "bucket",
+	}
+}
+
+// fakeETag returns the quoted upper case hex MD5 of the data like the OSS.
+func fakeETag(data []byte) string {
+	return fmt.Sprintf(`"%X"`, md5.Sum(data))
+}
+
+func (f *fakeOSS) put(key, data string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.objects[key] = []byte(data)
+}
+
+func (f *fakeOSS) get(key string) ([]byte, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	data, ok := f.objects[key]
+	return data, ok
+}
+
+func (f *fakeOSS) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	before := f.before
+	f.mu.Unlock()
+	if before != nil && before(w, r) {
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	key := strings.TrimPrefix(r.URL.Path, "/")
+	q := r.URL.Query()
+	_, uploads := q["uploads"]
+	_, uploadId := q["uploadId"]
+	switch {
+	case uploads || uploadId:
+		f.multipart(w, r, key)
+	case key == "" && r.Method == "GET":
+		f.list(w, r.URL.Query())
+	case r.Method == "GET" || r.Method == "HEAD":
+		data, ok := f.objects[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			if r.Method == "GET" {
+				xml.NewEncoder(w).Encode(Error{Code: "NoSuchKey"})
+			}
+			return
+		}
+		if r.Method == "GET" {
+			f.gets++
+		}
+		w.Header().Set("ETag", fakeETag(data))
+		http.ServeContent(w, r, key, f.modTime, bytes.NewReader(data))
+	case r.Method == "PUT":
+		data, _ := ioutil.ReadAll(r.Body)
+		f.objects[key] = data
+		w.Header().Set("ETag", fakeETag(data))
+	case r.Method == "DELETE":
+		delete(f.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusNotImplemented)
+	}
+}
+
+func (f *fakeOSS) multipart(w http.ResponseWriter, r *http.Request, key string) {
+	q := r.URL.Query()
+	if _, ok := q["uploads"]; ok {
+		f.nextId++
+		id := strconv.Itoa(f.nextId)
+		f.uploads[id] = map[int][]byte{}
+		xml.NewEncoder(w).Encode(InitiateMultipartUploadResult{Key: key, UploadId: id})
+		return
+	}
+
+	id := q.Get("uploadId")
+	parts, ok := f.uploads[id]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		xml.NewEncoder(w).Encode(Error{Code: "NoSuchUpload"})
+		return
+	}
+	switch r.Method {
+	case "PUT":
+		n, _ := strconv.Atoi(q.Get("partNumber"))
+		data, _ := ioutil.ReadAll(r.Body)
+		parts[n] = data
+		w.Header().Set("ETag", fakeETag(data))
+	case "POST":
+		var v CompleteMultipartUpload
+		xml.NewDecoder(r.Body).Decode(&v)
+		var data []byte
+		for i, p := range v.Part {
+			b, ok := parts[p.PartNumber]
+			if p.PartNumber != i+1 || !ok || p.ETag != fakeETag(b) {
+				w.WriteHeader(http.StatusBadRequest)
+				xml.NewEncoder(w).Encode(Error{Code: "InvalidPart"})
+				return
+			}
+			data = append(data, b...)
+		}
+		delete(f.uploads, id)
+		f.objects[key] = data
+		xml.NewEncoder(w).Encode(CompleteMultipartUploadResult{Key: key, ETag: `"MULTIPART"`})
+	case "DELETE":
+		delete(f.uploads, id)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (f *fakeOSS) list(w http.ResponseWriter, q url.Values) {
+	prefix, delimiter, marker := q.Get("prefix"), q.Get("delimiter"), q.Get("marker")
+	max, err := strconv.Atoi(q.Get("max-keys"))
+	if err != nil {
+		max = 1000
+	}
+	escape := func(s string) string {
+		if q.Get("encoding-type") == "url" {
+			return url.PathEscape(s)
+		}
+		return s
+	}
+
+	var keys []string
+	for k := range f.objects {
+		if strings.HasPrefix(k, prefix) && k > marker &&
+			!(delimiter != "" && strings.HasSuffix(marker, delimiter) && strings.HasPrefix(k, marker)) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	var v ListBucketResult
+	seen := map[string]bool{}
+	for _, k := range keys {
+		if n := strings.Index(k[len(prefix):], delimiter); delimiter != "" && n != -1 {
+			p := k[:len(prefix)+n+1]
+			if !seen[p] {
+				if len(v.Contents)+len(v.CommonPrefixes) == max {
+					v.IsTruncated = true
+					break
+				}
+				v.NextMarker = escape(p)
+				seen[p] = true
+				v.CommonPrefixes = append(v.CommonPrefixes, struct{ Prefix string }{escape(p)})
+			}
+			continue
+		}
+		if len(v.Contents)+len(v.CommonPrefixes) == max {
+			v.IsTruncated = true
+			break
+		}
+		v.NextMarker = escape(k)
+		v.Contents = append(v.Contents, struct {
+			Key          string
+			LastModified string
+			ETag         string
+			Type         string
+			Size         string
+			StorageClass string
+			Owner        Owner
+		}{Key: escape(k), LastModified: f.modTime.Format("2006-01-02T15:04:05.000Z"), Size: strconv.Itoa(len(f.objects[k]))})
+	}
+	if !v.IsTruncated {
+		v.NextMarker = ""
+	}
+	xml.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,339 @@
+// Copyright 2015 Chen Xianren. All rights reserved.
+
+//go:build go1.16
+// +build go1.16
+
+package oss
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"net/http"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var errSeekInvalid = errors.New("seek invalid")
+
+// FS is the read-only file system of the bucket objects under the prefix,
+// the "/" separated keys are the paths and the common prefixes are the directories.
+//
+// It implements the fs.FS, fs.ReadDirFS, fs.StatFS and fs.ReadFileFS,
+// for example, serve the static files:
+//
+//	http.Handle("/", http.FileServer(http.FS(b.NewFS("static"))))
+//
+// The files are read by the ranged Get from the current offset and support the io.Seeker.
+type FS struct {
+	b      Bucket
+	prefix string
+}
+
+// NewFS returns the FS of the bucket objects under the prefix,
+// the whole bucket if the prefix is the empty string.
+func (b Bucket) NewFS(prefix string) *FS {
+	prefix = strings.Trim(prefix, "/")
+	if prefix != "" {
+		prefix += "/"
+	}
+	return &FS{b, prefix}
+}
+
+// key returns the object key of the valid path name, the prefix for the root ".".
+func (f *FS) key(name string) string {
+	if name == "." {
+		return f.prefix
+	}
+	return f.prefix + name
+}
+
+// isNotFound reports whether the err is the OSS not found Error,
+// the HEAD response has no body so the Code is the status.
+func isNotFound(err error) bool {
+	e, ok := err.(Error)
+	return ok && (e.Code == "NoSuchKey" || strings.HasPrefix(e.Code, "404"))
+}
+
+func pathError(op, name string, err error) error {
+	if isNotFound(err) {
+		err = fs.ErrNotExist
+	}
+	return &fs.PathError{Op: op, Path: name, Err: err}
+}
+
+// Open implements the fs.FS.
+func (f *FS) Open(name string) (fs.File, error) {
+	fi, err := f.stat("open", name)
+	if err != nil {
+		return nil, err
+	}
+	if fi.IsDir() {
+		return &fsDir{fs: f, name: name, info: fi}, nil
+	}
+	return &fsFile{o: f.b.NewObject(f.key(name)), name: name, info: fi}, nil
+}
+
+// Stat implements the fs.StatFS, the Sys of the file is the http.Header of the Head.
+func (f *FS) Stat(name string) (fs.FileInfo, error) {
+	return f.stat("stat", name)
+}
+
+func (f *FS) stat(op, name string) (*fileInfo, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrInvalid}
+	}
+	if name == "." {
+		return &fileInfo{name: ".", dir: true}, nil
+	}
+
+	h, err := f.b.NewObject(f.key(name)).Head()
+	if err == nil {
+		fi := &fileInfo{name: path.Base(name), header: h}
+		if fi.size, err = strconv.ParseInt(h.Get("Content-Length"), 10, 64); err != nil {
+			return nil, pathError(op, name, err)
+		}
+		if t, err := time.Parse(http.TimeFormat, h.Get("Last-Modified")); err == nil {
+			fi.modTime = t.UTC()
+		}
+		return fi, nil
+	} else if !isNotFound(err) {
+		return nil, pathError(op, name, err)
+	}
+
+	r, err := f.b.ListObject(nil, Params{
+		"prefix":   {f.key(name) + "/"},
+		"max-keys": {"1"},
+	})
+	if err != nil {
+		return nil, pathError(op, name, err)
+	}
+	if len(r.Contents) == 0 && len(r.CommonPrefixes) == 0 {
+		return nil, pathError(op, name, fs.ErrNotExist)
+	}
+	return &fileInfo{name: path.Base(name), dir: true}, nil
+}
+
+// ReadDir implements the fs.ReadDirFS, the entries are sorted by the name.
+func (f *FS) ReadDir(name string) ([]fs.DirEntry, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrInvalid}
+	}
+	prefix := f.key(name)
+	if name != "." {
+		prefix += "/"
+	}
+
+	var (
+		entries []fs.DirEntry
+		found   bool
+	)
+	it := f.b.NewObjectIterator(nil, Params{
+		"prefix":    {prefix},
+		"delimiter": {"/"},
+		"max-keys":  {"1000"},
+	})
+	for it.Next() {
+		r := it.Result()
+		for _, i := range r.CommonPrefixes {
+			found = true
+			entries = append(entries, &fileInfo{name: path.Base(i.Prefix), dir: true})
+		}
+		for _, i := range r.Contents {
+			found = true
+			if i.Key == prefix { // the directory itself
+				continue
+			}
+			fi := &fileInfo{name: path.Base(i.Key)}
+			var err error
+			if fi.size, err = strconv.ParseInt(i.Size, 10, 64); err != nil {
+				return nil, pathError("readdir", name, err)
+			}
+			if t, err := time.Parse(time.RFC3339, i.LastModified); err == nil {
+				fi.modTime = t.UTC()
+			}
+			entries = append(entries, fi)
+		}
+	}
+	if err := it.Err(); err != nil {
+		return nil, pathError("readdir", name, err)
+	}
+
+	if !found && name != "." {
+		return nil, pathError("readdir", name, fs.ErrNotExist)
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name() < entries[j].Name()
+	})
+	return entries, nil
+}
+
+// ReadFile implements the fs.ReadFileFS.
+func (f *FS) ReadFile(name string) ([]byte, error) {
+	if !fs.ValidPath(name) || name == "." {
+		return nil, &fs.PathError{Op: "readfile", Path: name, Err: fs.ErrInvalid}
+	}
+	var v []byte
+	if err := f.b.NewObject(f.key(name)).Get(&v); err != nil {
+		return nil, pathError("readfile", name, err)
+	}
+	return v, nil
+}
+
+// fileInfo implements the fs.FileInfo and the fs.DirEntry.
+type fileInfo struct {
+	name    string
+	size    int64
+	modTime time.Time
+	dir     bool
+	header  http.Header
+}
+
+func (fi *fileInfo) Name() string       { return fi.name }
+func (fi *fileInfo) Size() int64        { return fi.size }
+func (fi *fileInfo) ModTime() time.Time { return fi.modTime }
+func (fi *fileInfo) IsDir() bool        { return fi.dir }
+func (fi *fileInfo) Sys() interface{}   { return fi.header }
+
+func (fi *fileInfo) Mode() fs.FileMode {
+	if fi.dir {
+		return fs.ModeDir | 0555
+	}
+	return 0444
+}
+
+func (fi *fileInfo) Type() fs.FileMode          { return fi.Mode().Type() }
+func (fi *fileInfo) Info() (fs.FileInfo, error) { return fi, nil }
+
+// fsFile is the object file, reads the response body of the ranged Get from the offset.
+type fsFile struct {
+	o      Object
+	name   string
+	info   *fileInfo
+	offset int64
+	body   io.ReadCloser
+	closed bool
+}
+
+func (f *fsFile) Stat() (fs.FileInfo, error) {
+	return f.info, nil
+}
+
+func (f *fsFile) Read(p []byte) (int, error) {
+	if f.closed {
+		return 0, &fs.PathError{Op: "read", Path: f.name, Err: fs.ErrClosed}
+	}
+	if f.offset >= f.info.size {
+		return 0, io.EOF
+	}
+	if len(p) == 0 {
+		return 0, nil
+	}
+	if f.body == nil {
+		res, err := f.o.GetResponse("GET", nil, Params{HeaderRange: {FormatRange(f.offset, 0)}})
+		if err == nil {
+			if err = newBody(res); err == nil {
+				err = readError(res)
+			}
+			if err != nil {
+				res.Body.Close()
+			}
+		}
+		if err != nil {
+			return 0, pathError("read", f.name, err)
+		}
+		f.body = res.Body
+	}
+	n, err := f.body.Read(p)
+	f.offset += int64(n)
+	if err == io.EOF && f.offset < f.info.size {
+		err = io.ErrUnexpectedEOF
+	}
+	return n, err
+}
+
+// Seek implements the io.Seeker, the next Read sends a new ranged Get if the offset changed.
+func (f *fsFile) Seek(offset int64, whence int) (int64, error) {
+	if f.closed {
+		return 0, &fs.PathError{Op: "seek", Path: f.name, Err: fs.ErrClosed}
+	}
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += f.offset
+	case io.SeekEnd:
+		offset += f.info.size
+	default:
+		return 0, &fs.PathError{Op: "seek", Path: f.name, Err: errSeekInvalid}
+	}
+	if offset < 0 {
+		return 0, &fs.PathError{Op: "seek", Path: f.name, Err: errSeekInvalid}
+	}
+	if offset != f.offset && f.body != nil {
+		f.body.Close()
+		f.body = nil
+	}
+	f.offset = offset
+	return offset, nil
+}
+
+func (f *fsFile) Close() error {
+	if f.closed {
+		return &fs.PathError{Op: "close", Path: f.name, Err: fs.ErrClosed}
+	}
+	f.closed = true
+	if f.body != nil {
+		return f.body.Close()
+	}
+	return nil
+}
+
+// fsDir is the directory file, the entries are read on the first ReadDir.
+type fsDir struct {
+	fs      *FS
+	name    string
+	info    *fileInfo
+	entries []fs.DirEntry
+	read    bool
+}
+
+func (d *fsDir) Stat() (fs.FileInfo, error) {
+	return d.info, nil
+}
+
+func (d *fsDir) Read([]byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: d.name, Err: errors.New("is a directory")}
+}
+
+func (d *fsDir) Close() error {
+	return nil
+}
+
+// ReadDir implements the fs.ReadDirFile.
+func (d *fsDir) ReadDir(n int) ([]fs.DirEntry, error) {
+	if !d.read {
+		entries, err := d.fs.ReadDir(d.name)
+		if err != nil {
+			return nil, err
+		}
+		d.entries, d.read = entries, true
+	}
+	if n <= 0 {
+		entries := d.entries
+		d.entries = nil
+		return entries, nil
+	}
+	if len(d.entries) == 0 {
+		return nil, io.EOF
+	}
+	if n > len(d.entries) {
+		n = len(d.entries)
+	}
+	entries := d.entries[:n]
+	d.entries = d.entries[n:]
+	return entries, nil
+}
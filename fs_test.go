@@ -0,0 +1,52 @@
+// Copyright 2015 Chen Xianren. All rights reserved.
+
+//go:build go1.16
+// +build go1.16
+
+package oss
+
+import (
+	"bytes"
+	"errors"
+	"io/fs"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+	"text/template"
+)
+
+func TestFS(t *testing.T) {
+	f, b := newFakeOSS(t)
+	f.put("site/index.html", "<h1>{{.}}</h1>")
+	f.put("site/css/main.css", "body{}")
+	f.put("site/empty/", "")
+	f.put("site/a b/c+d.txt", HelloWorld)
+	f.put("other.txt", "other")
+
+	fsys := b.NewFS("/site/")
+	fatal(t, fstest.TestFS(fsys, "index.html", "css/main.css", "a b/c+d.txt", "empty"))
+
+	_, err := fsys.Open("other.txt")
+	equal(t, "not exist", true, errors.Is(err, fs.ErrNotExist))
+
+	tpl, err := template.ParseFS(fsys, "*.html")
+	fatal(t, err)
+	var buf bytes.Buffer
+	fatal(t, tpl.Execute(&buf, "hello"))
+	equal(t, "template", "<h1>hello</h1>", buf.String())
+
+	s := httptest.NewServer(http.FileServer(http.FS(fsys)))
+	defer s.Close()
+	req, err := http.NewRequest("GET", s.URL+"/a%20b/c+d.txt", nil)
+	fatal(t, err)
+	req.Header.Set("Range", "bytes=3-12")
+	res, err := (&http.Client{Transport: &http.Transport{}}).Do(req)
+	fatal(t, err)
+	data, err := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+	fatal(t, err)
+	equal(t, "status", http.StatusPartialContent, res.StatusCode)
+	equal(t, "range", HelloWorld[3:13], string(data))
+}
@@ -0,0 +1,232 @@
+// Copyright 2015 Chen Xianren. All rights reserved.
+
+package oss
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// QueryProcess is the query parameter of the data processing.
+const QueryProcess = "x-oss-process"
+
+// Image resize modes
+const (
+	ResizeLfit  = "lfit"
+	ResizeMfit  = "mfit"
+	ResizeFill  = "fill"
+	ResizePad   = "pad"
+	ResizeFixed = "fixed"
+)
+
+// Watermark positions
+const (
+	WatermarkNorthWest = "nw"
+	WatermarkNorth     = "north"
+	WatermarkNorthEast = "ne"
+	WatermarkWest      = "west"
+	WatermarkCenter    = "center"
+	WatermarkEast      = "east"
+	WatermarkSouthWest = "sw"
+	WatermarkSouth     = "south"
+	WatermarkSouthEast = "se"
+)
+
+var errProcessRequired = errors.New("process required")
+
+// ImageProcess is the fluent builder of the image processing, for example:
+//
+//	NewImageProcess().Resize(ResizeLfit, 100, 100).Format("webp").String()
+//
+// returns "image/resize,m_lfit,w_100,h_100/format,webp".
+//
+// The zero or empty parameters are omitted.
+//
+// Relevant documentation:
+//
+// https://help.aliyun.com/document_detail/44686.html
+type ImageProcess struct {
+	actions []string
+}
+
+// NewImageProcess returns a new empty ImageProcess.
+func NewImageProcess() *ImageProcess {
+	return new(ImageProcess)
+}
+
+// Action appends an action given a name and the parameters such as "w_100".
+func (p *ImageProcess) Action(name string, params ...string) *ImageProcess {
+	a := []string{name}
+	for _, i := range params {
+		if i != "" {
+			a = append(a, i)
+		}
+	}
+	p.actions = append(p.actions, strings.Join(a, ","))
+	return p
+}
+
+// Resize the image given a mode and the width and the height.
+func (p *ImageProcess) Resize(mode string, width, height int) *ImageProcess {
+	return p.Action("resize", processParam("m", mode), processParamInt("w", width), processParamInt("h", height))
+}
+
+// Crop the image given the origin and the width and the height.
+func (p *ImageProcess) Crop(x, y, width, height int) *ImageProcess {
+	return p.Action("crop", processParamInt("x", x), processParamInt("y", y), processParamInt("w", width), processParamInt("h", height))
+}
+
+// Rotate the image clockwise given the degree in [0, 360].
+func (p *ImageProcess) Rotate(degree int) *ImageProcess {
+	return p.Action("rotate", strconv.Itoa(degree))
+}
+
+// Format converts the image format, such as jpg, png, webp, bmp, gif, tiff.
+func (p *ImageProcess) Format(format string) *ImageProcess {
+	return p.Action("format", format)
+}
+
+// Quality sets the relative quality in [1, 100] of the jpg or webp image.
+func (p *ImageProcess) Quality(quality int) *ImageProcess {
+	return p.Action("quality", processParamInt("q", quality))
+}
+
+// Watermark adds the text or the image watermark.
+func (p *ImageProcess) Watermark(w Watermark) *ImageProcess {
+	return p.Action("watermark", w.params()...)
+}
+
+// String returns the x-oss-process value.
+func (p *ImageProcess) String() string {
+	if len(p.actions) == 0 {
+		return ""
+	}
+	return "image/" + strings.Join(p.actions, "/")
+}
+
+// Watermark represents the text or the image watermark,
+// the Image is the object name in the same bucket.
+//
+// The Color is the RGB hex without "#" such as "FFFFFF",
+// the Transparency in [0, 100] and 0 means the default 100.
+type Watermark struct {
+	Text         string
+	Font         string
+	Color        string
+	Size         int
+	Image        string
+	Transparency int
+	Position     string
+	X            int
+	Y            int
+}
+
+func (w Watermark) params() []string {
+	return []string{
+		processParam("text", encodeProcess(w.Text)),
+		processParam("type", encodeProcess(w.Font)),
+		processParam("color", w.Color),
+		processParamInt("size", w.Size),
+		processParam("image", encodeProcess(w.Image)),
+		processParamInt("t", w.Transparency),
+		processParam("g", w.Position),
+		processParamInt("x", w.X),
+		processParamInt("y", w.Y),
+	}
+}
+
+func processParam(k, v string) string {
+	if v == "" {
+		return ""
+	}
+	return k + "_" + v
+}
+
+func processParamInt(k string, v int) string {
+	if v == 0 {
+		return ""
+	}
+	return k + "_" + strconv.Itoa(v)
+}
+
+// encodeProcess returns the URL safe base64 of the s used in the process parameters.
+func encodeProcess(s string) string {
+	if s == "" {
+		return ""
+	}
+	return base64.URLEncoding.EncodeToString([]byte(s))
+}
+
+// Process get the processed object content to the data given the process such as "image/resize,w_100".
+//
+// The first optional Params is for Header, the second is for Query.
+//
+// The data's type must be
+// *[]byte, *os.File, *bytes.Buffer
+//
+// Use the method SignURL with the x-oss-process query to get the processed object signed URL.
+//
+// Relevant documentation:
+//
+// https://help.aliyun.com/document_detail/44686.html
+func (o Object) Process(process string, data interface{}, args ...Params) error {
+	if process == "" {
+		return errProcessRequired
+	}
+	header, query := getHeaderQuery(args)
+	query.Set(QueryProcess, process)
+	return o.Get(data, header, query)
+}
+
+// ProcessSaveAs process the object and save the result as the target object,
+// the target object's bucket name is the same as the source if it is the empty string.
+//
+// The first optional Params is for Header, the second is for Query.
+//
+// Relevant documentation:
+//
+// https://help.aliyun.com/document_detail/55811.html
+func (o Object) ProcessSaveAs(process string, target Object, args ...Params) (*ProcessSaveAsResult, error) {
+	if process == "" {
+		return nil, errProcessRequired
+	}
+	if target.Name == "" {
+		return nil, errObjectNameRequired
+	}
+
+	header, query := getHeaderQuery(args)
+	query.Set(QueryProcess, "")
+
+	process += "|sys/saveas,o_" + encodeProcess(target.Name)
+	if target.Bucket.Name != "" {
+		process += ",b_" + encodeProcess(target.Bucket.Name)
+	}
+
+	res, err := o.GetResponse("POST", []byte(QueryProcess+"="+process), header, query)
+	if err != nil {
+		return nil, err
+	}
+
+	var b []byte
+	if err = ReadBody(res, &b); err != nil {
+		return nil, err
+	}
+
+	v := new(ProcessSaveAsResult)
+	if err = json.Unmarshal(b, v); err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+// ProcessSaveAsResult represents the process and save as result.
+type ProcessSaveAsResult struct {
+	Bucket   string `json:"bucket"`
+	FileSize int64  `json:"fileSize"`
+	Object   string `json:"object"`
+	Status   string `json:"status"`
+}
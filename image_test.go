@@ -0,0 +1,71 @@
+// Copyright 2015 Chen Xianren. All rights reserved.
+
+package oss
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestImageProcess(t *testing.T) {
+	p := NewImageProcess().
+		Resize(ResizeLfit, 100, 0).
+		Crop(0, 10, 50, 50).
+		Rotate(90).
+		Quality(80).
+		Format("webp").
+		Watermark(Watermark{Text: "Hello 世界", Size: 20, Position: WatermarkSouthEast, X: 10})
+	equal(t, "ImageProcess",
+		"image/resize,m_lfit,w_100/crop,y_10,w_50,h_50/rotate,90/quality,q_80/format,webp/watermark,text_SGVsbG8g5LiW55WM,size_20,g_se,x_10",
+		p.String())
+	equal(t, "empty ImageProcess", "", NewImageProcess().String())
+
+	u, err := url.Parse("http://bucket.oss-cn-hangzhou.aliyuncs.com/a.jpg?x-oss-process=image%2Fresize%2Cw_100&foo=bar")
+	fatal(t, err)
+	equal(t, "CanonicalizedResource", "/bucket/a.jpg?x-oss-process=image/resize,w_100", CanonicalizedResource(u))
+
+	o := Object{Bucket: sb, Name: "a.jpg"}
+	s, err := o.SignURL("GET", 60, nil, Params{QueryProcess: {p.String()}})
+	fatal(t, err)
+	if !strings.Contains(s, "Signature=") || !strings.Contains(s, QueryProcess+"=") {
+		t.Fatal("SignURL", s)
+	}
+	_, err = o.SignURL("GET", 0)
+	equal(t, "SignURL expires", errExpiresInvalid, err)
+}
+
+func TestObjectProcess(t *testing.T) {
+	o := newObject()
+	o.Name = "aliyun-oss-go-sdk.png"
+
+	fatal(t, o.Bucket.Put())
+
+	var buf bytes.Buffer
+	fatal(t, png.Encode(&buf, image.NewGray(image.Rect(0, 0, 200, 100))))
+	_, err := o.Put(buf.Bytes())
+	fatal(t, err)
+
+	p := NewImageProcess().Resize(ResizeFixed, 50, 20).String()
+
+	var v []byte
+	fatal(t, o.Process(p, &v))
+	c, err := png.DecodeConfig(bytes.NewReader(v))
+	fatal(t, err)
+	equal(t, "Width", 50, c.Width)
+	equal(t, "Height", 20, c.Height)
+
+	target := Object{Name: "aliyun-oss-go-sdk-thumbnail.png"}
+	r, err := o.ProcessSaveAs(p, target)
+	fatal(t, err)
+	equal(t, "Object", target.Name, r.Object)
+
+	target.Bucket = o.Bucket
+	fatal(t, target.Delete())
+	fatal(t, o.Delete())
+
+	fatal(t, o.Bucket.Delete())
+}
@@ -0,0 +1,418 @@
+// Copyright 2015 Chen Xianren. All rights reserved.
+
+package oss
+
+import (
+	"encoding/xml"
+	"errors"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LiveChannel status
+const (
+	LiveChannelEnabled  = "enabled"
+	LiveChannelDisabled = "disabled"
+)
+
+// LiveChannel stat status
+const (
+	LiveChannelIdle = "Idle"
+	LiveChannelLive = "Live"
+)
+
+// LiveChannelTypeHLS is the only supported LiveChannel target type.
+const LiveChannelTypeHLS = "HLS"
+
+var (
+	errLiveChannelNameRequired = errors.New("live channel name required")
+	errLiveChannelStatus       = errors.New("live channel status must be enabled or disabled")
+	errPlaylistNameInvalid     = errors.New("playlist name must end with .m3u8")
+	errTimeRangeInvalid        = errors.New("time range invalid")
+)
+
+// PutLiveChannel create or update the LiveChannel given a name,
+// returns the RTMP publish URLs and the HLS play URLs.
+//
+// The first optional Params is for Header, the second is for Query.
+//
+// The publish URLs need to be signed by the method SignRTMPURL if the bucket is not public-read-write.
+//
+// Relevant documentation:
+//
+// https://help.aliyun.com/document_detail/44294.html
+func (b Bucket) PutLiveChannel(name string, config LiveChannelConfiguration, args ...Params) (*CreateLiveChannelResult, error) {
+	if name == "" {
+		return nil, errLiveChannelNameRequired
+	}
+
+	header, query := getHeaderQuery(args)
+	query.Set("live", "")
+
+	v := new(CreateLiveChannelResult)
+
+	err := b.Do("PUT", name, config, v, header, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+// PutLiveChannelStatus enable or disable the LiveChannel given a name,
+// the streaming is interrupted when disabled.
+//
+// The first optional Params is for Header, the second is for Query.
+//
+// Relevant documentation:
+//
+// https://help.aliyun.com/document_detail/44295.html
+func (b Bucket) PutLiveChannelStatus(name, status string, args ...Params) error {
+	if name == "" {
+		return errLiveChannelNameRequired
+	}
+	if status != LiveChannelEnabled && status != LiveChannelDisabled {
+		return errLiveChannelStatus
+	}
+
+	header, query := getHeaderQuery(args)
+	query.Set("live", "")
+	query.Set("status", status)
+
+	return b.Do("PUT", name, nil, nil, header, query)
+}
+
+// GetLiveChannelInfo returns the LiveChannel configuration given a name.
+//
+// The first optional Params is for Header, the second is for Query.
+//
+// Relevant documentation:
+//
+// https://help.aliyun.com/document_detail/44297.html
+func (b Bucket) GetLiveChannelInfo(name string, args ...Params) (*LiveChannelConfiguration, error) {
+	if name == "" {
+		return nil, errLiveChannelNameRequired
+	}
+
+	header, query := getHeaderQuery(args)
+	query.Set("live", "")
+
+	v := new(LiveChannelConfiguration)
+
+	err := b.Do("GET", name, nil, v, header, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+// GetLiveChannelStat returns the LiveChannel streaming status given a name.
+//
+// The first optional Params is for Header, the second is for Query.
+//
+// Relevant documentation:
+//
+// https://help.aliyun.com/document_detail/44299.html
+func (b Bucket) GetLiveChannelStat(name string, args ...Params) (*LiveChannelStat, error) {
+	if name == "" {
+		return nil, errLiveChannelNameRequired
+	}
+
+	header, query := getHeaderQuery(args)
+	query.Set("live", "")
+	query.Set("comp", "stat")
+
+	v := new(LiveChannelStat)
+
+	err := b.Do("GET", name, nil, v, header, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+// GetLiveChannelHistory returns the recent streaming records of the LiveChannel given a name.
+//
+// The first optional Params is for Header, the second is for Query.
+//
+// Relevant documentation:
+//
+// https://help.aliyun.com/document_detail/44301.html
+func (b Bucket) GetLiveChannelHistory(name string, args ...Params) (*LiveChannelHistory, error) {
+	if name == "" {
+		return nil, errLiveChannelNameRequired
+	}
+
+	header, query := getHeaderQuery(args)
+	query.Set("live", "")
+	query.Set("comp", "history")
+
+	v := new(LiveChannelHistory)
+
+	err := b.Do("GET", name, nil, v, header, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+// ListLiveChannel returns the LiveChannels of the bucket.
+//
+// The first optional Params is for Header, the second is for Query.
+//
+// Query predefine parameters: prefix, marker, max-keys.
+//
+// Relevant documentation:
+//
+// https://help.aliyun.com/document_detail/44298.html
+func (b Bucket) ListLiveChannel(args ...Params) (*ListLiveChannelResult, error) {
+	header, query := getHeaderQuery(args)
+	query.Set("live", "")
+
+	v := new(ListLiveChannelResult)
+
+	err := b.Do("GET", "", nil, v, header, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+// DeleteLiveChannel delete the LiveChannel given a name,
+// the generated files are not deleted.
+//
+// The first optional Params is for Header, the second is for Query.
+//
+// Relevant documentation:
+//
+// https://help.aliyun.com/document_detail/44296.html
+func (b Bucket) DeleteLiveChannel(name string, args ...Params) error {
+	if name == "" {
+		return errLiveChannelNameRequired
+	}
+
+	header, query := getHeaderQuery(args)
+	query.Set("live", "")
+
+	return b.Do("DELETE", name, nil, nil, header, query)
+}
+
+// PostVodPlaylist generate the VOD playlist of the LiveChannel given a name,
+// a playlist name which must end with ".m3u8" and the time range of the ts files,
+// the playlist is saved as the object "name/playlist".
+//
+// The first optional Params is for Header, the second is for Query.
+//
+// Relevant documentation:
+//
+// https://help.aliyun.com/document_detail/44302.html
+func (b Bucket) PostVodPlaylist(name, playlist string, start, end time.Time, args ...Params) error {
+	if name == "" {
+		return errLiveChannelNameRequired
+	}
+	if !strings.HasSuffix(playlist, ".m3u8") || strings.Contains(playlist, "/") {
+		return errPlaylistNameInvalid
+	}
+
+	header, query := getHeaderQuery(args)
+	if err := setVodTime(query, start, end); err != nil {
+		return err
+	}
+
+	return b.Do("POST", name+"/"+playlist, nil, nil, header, query)
+}
+
+// GetVodPlaylist returns the VOD playlist content of the LiveChannel given a name
+// and the time range of the ts files.
+//
+// The first optional Params is for Header, the second is for Query.
+//
+// Relevant documentation:
+//
+// https://help.aliyun.com/document_detail/48130.html
+func (b Bucket) GetVodPlaylist(name string, start, end time.Time, args ...Params) ([]byte, error) {
+	if name == "" {
+		return nil, errLiveChannelNameRequired
+	}
+
+	header, query := getHeaderQuery(args)
+	if err := setVodTime(query, start, end); err != nil {
+		return nil, err
+	}
+
+	var v []byte
+
+	err := b.Do("GET", name, nil, &v, header, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+// setVodTime set the vod query given the time range which must be less than one day.
+func setVodTime(query Params, start, end time.Time) error {
+	if !start.Before(end) || end.Sub(start) > 24*time.Hour {
+		return errTimeRangeInvalid
+	}
+	query.Set("vod", "")
+	query.Set("startTime", strconv.FormatInt(start.Unix(), 10))
+	query.Set("endTime", strconv.FormatInt(end.Unix(), 10))
+	return nil
+}
+
+// SignRTMPURL returns the signed RTMP publish URL of the LiveChannel given a name,
+// an optional playlist name and the expires seconds.
+//
+// The signature is the same HMAC-SHA1 as the method Signature
+// but the canonicalized parameters replace the headers.
+//
+// Relevant documentation:
+//
+// https://help.aliyun.com/document_detail/44293.html
+func (b Bucket) SignRTMPURL(name, playlist string, seconds int) (string, error) {
+	if b.Name == "" {
+		return "", errBucketNameRequired
+	}
+	if name == "" {
+		return "", errLiveChannelNameRequired
+	}
+	if seconds <= 0 {
+		return "", errExpiresInvalid
+	}
+	if b.AccessKeyId == "" || b.AccessKeySecret == "" {
+		return "", errAccessKeyRequired
+	}
+
+	params := url.Values{}
+	if playlist != "" {
+		params.Set("playlistName", playlist)
+	}
+	if b.SecurityToken != "" {
+		params.Set("security-token", b.SecurityToken)
+	}
+
+	keys := make([]string, 0, len(params))
+	for k := range params {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	expires := strconv.FormatInt(time.Now().Add(time.Duration(seconds)*time.Second).Unix(), 10)
+	s := expires + "\n"
+	for _, k := range keys {
+		s += k + ":" + params.Get(k) + "\n"
+	}
+	s += "/" + b.Name + "/" + name
+
+	params.Set("OSSAccessKeyId", b.AccessKeyId)
+	params.Set("Expires", expires)
+	params.Set("Signature", HmacSha1(b.AccessKeySecret, s))
+
+	u := url.URL{
+		Scheme:   "rtmp",
+		Host:     b.Name + "." + b.Host(),
+		Path:     "/live/" + name,
+		RawQuery: params.Encode(),
+	}
+
+	return u.String(), nil
+}
+
+// LiveChannelConfiguration represents the LiveChannel configuration.
+//
+// Relevant documentation:
+//
+// https://help.aliyun.com/document_detail/44294.html
+type LiveChannelConfiguration struct {
+	XMLName     xml.Name `xml:"LiveChannelConfiguration"`
+	Description string   `xml:",omitempty"`
+	Status      string   `xml:",omitempty"`
+	Target      LiveChannelTarget
+	Snapshot    *LiveChannelSnapshot `xml:",omitempty"`
+}
+
+// LiveChannelTarget represents the LiveChannel target,
+// the Type is HLS and the PlaylistName must end with ".m3u8".
+type LiveChannelTarget struct {
+	Type         string
+	FragDuration int    `xml:",omitempty"`
+	FragCount    int    `xml:",omitempty"`
+	PlaylistName string `xml:",omitempty"`
+}
+
+// LiveChannelSnapshot represents the LiveChannel snapshot configuration,
+// the snapshots are saved to the DestBucket and notified to the MNS NotifyTopic.
+type LiveChannelSnapshot struct {
+	RoleName    string
+	DestBucket  string
+	NotifyTopic string
+	Interval    int
+}
+
+// CreateLiveChannelResult represents the create LiveChannel result.
+type CreateLiveChannelResult struct {
+	PublishUrls []string `xml:"PublishUrls>Url"`
+	PlayUrls    []string `xml:"PlayUrls>Url"`
+}
+
+// LiveChannelStat represents the LiveChannel streaming status,
+// the Video and the Audio are nil if the Status is Idle.
+//
+// Relevant documentation:
+//
+// https://help.aliyun.com/document_detail/44299.html
+type LiveChannelStat struct {
+	Status        string
+	ConnectedTime string // 2006-01-02T15:04:05.000Z
+	RemoteAddr    string
+	Video         *struct {
+		Width     int
+		Height    int
+		FrameRate int
+		Bandwidth int
+		Codec     string
+	}
+	Audio *struct {
+		Bandwidth  int
+		SampleRate int
+		Codec      string
+	}
+}
+
+// LiveChannelHistory represents the recent streaming records of the LiveChannel.
+type LiveChannelHistory struct {
+	LiveRecord []struct {
+		StartTime  string // 2006-01-02T15:04:05.000Z
+		EndTime    string // 2006-01-02T15:04:05.000Z
+		RemoteAddr string
+	}
+}
+
+// ListLiveChannelResult represents the list LiveChannel result.
+//
+// Relevant documentation:
+//
+// https://help.aliyun.com/document_detail/44298.html
+type ListLiveChannelResult struct {
+	Prefix      string
+	Marker      string
+	MaxKeys     int
+	IsTruncated bool
+	NextMarker  string
+	LiveChannel []struct {
+		Name         string
+		Description  string
+		Status       string
+		LastModified string   // 2006-01-02T15:04:05.000Z
+		PublishUrls  []string `xml:"PublishUrls>Url"`
+		PlayUrls     []string `xml:"PlayUrls>Url"`
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright 2015 Chen Xianren. All rights reserved.
+
+package oss
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSignRTMPURL(t *testing.T) {
+	b := Bucket{Service: sb.Service, Name: "bucket"}
+
+	s, err := b.SignRTMPURL("channel", "playlist.m3u8", 60)
+	fatal(t, err)
+
+	u, err := url.Parse(s)
+	fatal(t, err)
+	equal(t, "Scheme", "rtmp", u.Scheme)
+	equal(t, "Host", "bucket."+b.Host(), u.Host)
+	equal(t, "Path", "/live/channel", u.Path)
+
+	q := u.Query()
+	equal(t, "OSSAccessKeyId", b.AccessKeyId, q.Get("OSSAccessKeyId"))
+	equal(t, "playlistName", "playlist.m3u8", q.Get("playlistName"))
+	sig := HmacSha1(b.AccessKeySecret, q.Get("Expires")+"\nplaylistName:playlist.m3u8\n/bucket/channel")
+	equal(t, "Signature", sig, q.Get("Signature"))
+
+	_, err = b.SignRTMPURL("channel", "", 0)
+	equal(t, "expires", errExpiresInvalid, err)
+}
+
+func TestBucketLiveChannel(t *testing.T) {
+	b := newBucket()
+
+	fatal(t, b.Put())
+
+	name := "aliyun-oss-go-sdk"
+	r, err := b.PutLiveChannel(name, LiveChannelConfiguration{
+		Description: "test",
+		Status:      LiveChannelEnabled,
+		Target: LiveChannelTarget{
+			Type:         LiveChannelTypeHLS,
+			FragDuration: 5,
+			FragCount:    3,
+			PlaylistName: "playlist.m3u8",
+		},
+	})
+	fatal(t, err)
+	if len(r.PublishUrls) != 1 || !strings.HasPrefix(r.PublishUrls[0], "rtmp://") {
+		t.Fatal("PublishUrls", r.PublishUrls)
+	}
+
+	c, err := b.GetLiveChannelInfo(name)
+	fatal(t, err)
+	equal(t, "Description", "test", c.Description)
+	equal(t, "FragCount", 3, c.Target.FragCount)
+
+	fatal(t, b.PutLiveChannelStatus(name, LiveChannelDisabled))
+
+	s, err := b.GetLiveChannelStat(name)
+	fatal(t, err)
+	equal(t, "Status", "Disabled", s.Status)
+
+	_, err = b.GetLiveChannelHistory(name)
+	fatal(t, err)
+
+	l, err := b.ListLiveChannel(Params{}, Params{"prefix": {name}})
+	fatal(t, err)
+	equal(t, "ListLiveChannel", 1, len(l.LiveChannel))
+
+	now := time.Now()
+	equal(t, "time range", errTimeRangeInvalid, b.PostVodPlaylist(name, "vod.m3u8", now, now))
+
+	fatal(t, b.DeleteLiveChannel(name))
+
+	fatal(t, b.Delete())
+}
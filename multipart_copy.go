@@ -0,0 +1,130 @@
+// Copyright 2015 Chen Xianren. All rights reserved.
+
+package oss
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Multipart Copy defaults and limits
+const (
+	MultipartCopyPartSize    = 10 << 20 // 10M
+	MultipartCopyMinPartSize = 100 << 10
+	MultipartCopyMaxParts    = 10000
+)
+
+var copyHeaders = []string{
+	"Cache-Control",
+	"Content-Disposition",
+	"Content-Encoding",
+	"Content-Language",
+	"Content-Type",
+	"Expires",
+}
+
+// MultipartCopy copy the object content from the source object by the Multipart Upload,
+// the source object is split into parts of the partSize and
+// at most routines parts are copied in parallel.
+//
+// The first optional Params is for Header, the second is for Query,
+// both are sent with the InitiateMultipartUpload and are not changed.
+//
+// If the header x-oss-metadata-directive is REPLACE then the given header is used as the metadata,
+// otherwise the source object metadata is preserved, same as the method Copy.
+//
+// The partSize will be increased if the parts are more than 10000,
+// the MultipartCopyPartSize is used if the partSize <= 0.
+//
+// The Multipart Upload is aborted if any part failed.
+//
+// Relevant documentation:
+//
+// https://docs.aliyun.com/#/pub/oss/api-reference/multipart-upload&UploadPartCopy
+func (o Object) MultipartCopy(source Object, partSize int64, routines int, args ...Params) (*CompleteMultipartUploadResult, error) {
+	if source.FullName() == "" {
+		return nil, errSourceObjectInvalid
+	}
+
+	h, err := source.Head()
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := strconv.ParseInt(h.Get("Content-Length"), 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	if partSize <= 0 {
+		partSize = MultipartCopyPartSize
+	} else if partSize < MultipartCopyMinPartSize {
+		partSize = MultipartCopyMinPartSize
+	}
+	if n := (total + MultipartCopyMaxParts - 1) / MultipartCopyMaxParts; partSize < n {
+		partSize = n
+	}
+	if routines <= 0 {
+		routines = 1
+	}
+
+	header, query := Params{}, Params{}
+	header.Copy(getParams(args, 0))
+	query.Copy(getParams(args, 1))
+	directive := ""
+	for k, v := range header {
+		if strings.EqualFold(k, "x-oss-metadata-directive") {
+			if len(v) > 0 {
+				directive = v[0]
+			}
+			delete(header, k)
+		}
+	}
+	if !strings.EqualFold(directive, "REPLACE") {
+		copyMetadata(header, h)
+	}
+	if o.ACL != "" {
+		header.Set("x-oss-object-acl", o.ACL)
+	}
+
+	n := int((total + partSize - 1) / partSize)
+	if n == 0 { // empty source object
+		n = 1
+	}
+
+	return o.multipartUpload(n, routines, func(partNumber int, uploadId string) (string, error) {
+		var (
+			cpr *CopyPartResult
+			err error
+		)
+		if total == 0 {
+			cpr, err = o.UploadPartCopy(partNumber, uploadId, source)
+		} else {
+			first := int64(partNumber-1) * partSize
+			length := partSize
+			if first+length > total {
+				length = total - first
+			}
+			cpr, err = o.UploadPartCopyRange(partNumber, uploadId, source, first, length)
+		}
+		if err != nil {
+			return "", err
+		}
+		return cpr.ETag, nil
+	}, header, query)
+}
+
+// copyMetadata copy the standard HTTP headers and the user metadata from src to dst.
+func copyMetadata(dst Params, src http.Header) {
+	for _, k := range copyHeaders {
+		if v := src.Get(k); v != "" {
+			dst.Set(k, v)
+		}
+	}
+	for k, v := range src {
+		if strings.HasPrefix(strings.ToLower(k), "x-oss-meta-") && len(v) > 0 {
+			dst.Set(k, v[0])
+		}
+	}
+}
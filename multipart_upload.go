@@ -7,7 +7,9 @@ import (
 	"time"
 )
 
-// InitiateMultipartUpload initialize a Multipart Upload event.
+// InitiateMultipartUpload initialize a Multipart Upload event,
+// also send the storage class if it is not the empty string
+// and the tagging and the encryption if they are not empty.
 //
 // The first optional Params is for Header, the second is for Query.
 //
@@ -17,6 +19,13 @@ import (
 func (o Object) InitiateMultipartUpload(args ...Params) (*InitiateMultipartUploadResult, error) {
 	header, query := getHeaderQuery(args)
 	query.Set("uploads", "")
+	if o.StorageClass != "" {
+		header.Set("x-oss-storage-class", o.StorageClass)
+	}
+	if o.Tagging != nil && len(o.Tagging.TagSet.Tag) > 0 {
+		header.Set(HeaderTagging, o.Tagging.Encode())
+	}
+	o.Encryption.setHeader(header)
 
 	v := new(InitiateMultipartUploadResult)
 
@@ -86,7 +95,7 @@ func (o Object) UploadPartCopy(partNumber int, uploadId string, source Object, a
 	if uploadId == "" {
 		return nil, errUploadIdRequired
 	}
-	s := source.FullName()
+	s := source.copySource()
 	if s == "" {
 		return nil, errSourceObjectInvalid
 	}
@@ -107,6 +116,29 @@ func (o Object) UploadPartCopy(partNumber int, uploadId string, source Object, a
 	return v, nil
 }
 
+// UploadPartCopyRange upload a part copy from the source object range given the first-byte-pos and the length,
+// a partNumber and a uploadId returns the ETag.
+//
+// The first optional Params is for Header, the second is for Query.
+//
+// The partNumber must be gte 1 and lte 10000.
+// See the function FormatRange to get more about the first and the length.
+//
+// Relevant documentation:
+//
+// https://docs.aliyun.com/#/pub/oss/api-reference/multipart-upload&UploadPartCopy
+func (o Object) UploadPartCopyRange(partNumber int, uploadId string, source Object, first, length int64, args ...Params) (*CopyPartResult, error) {
+	r := FormatRange(first, length)
+	if r == "" {
+		return nil, errRangeInvalid
+	}
+
+	header, query := getHeaderQuery(args)
+	header.Set(HeaderCopySourceRange, r)
+
+	return o.UploadPartCopy(partNumber, uploadId, source, header, query)
+}
+
 // CompleteMultipartUpload complete the Multipart Upload given a uploadId, all the partNumbers and ETags.
 //
 // The first optional Params is for Header, the second is for Query.
@@ -262,7 +294,7 @@ type ListMultipartUploadsResult struct {
 	KeyMarker        string
 	UploadIdMarker   string
 	NextKeyMarker    string
-	NextUploadMarker string
+	NextUploadMarker string `xml:"NextUploadIdMarker"`
 	MaxUploads       int
 	IsTruncated      bool
 	Upload           []struct {
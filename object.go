@@ -8,10 +8,18 @@ import (
 )
 
 // Object represents a OSS object, the Name is required.
+//
+// The VersionId selects a version of the object in a versioning enabled bucket,
+// it is used by Get, Range, Head, Delete, the ACL, the tagging, the symlink, the restore
+// and as the source of Copy and UploadPartCopy.
 type Object struct {
 	Bucket
-	Name string
-	ACL  string
+	Name         string
+	ACL          string
+	StorageClass string
+	Tagging      *Tagging
+	VersionId    string
+	Encryption   ServerSideEncryption
 }
 
 // FullName returns the string "/BucketName/ObjectName".
@@ -24,6 +32,23 @@ func (o Object) FullName() string {
 	return ""
 }
 
+// copySource returns the x-oss-copy-source header,
+// the FullName with the versionId if the VersionId is not the empty string.
+func (o Object) copySource() string {
+	s := o.FullName()
+	if s != "" && o.VersionId != "" {
+		s += "?versionId=" + o.VersionId
+	}
+	return s
+}
+
+// setVersionId set the query versionId if the VersionId is not the empty string.
+func (o Object) setVersionId(query Params) {
+	if o.VersionId != "" {
+		query.Set("versionId", o.VersionId)
+	}
+}
+
 // Do sends an HTTP request to OSS and read the HTTP response to v.
 //
 // The first optional Params is for Header, the second is for Query.
@@ -60,8 +85,30 @@ func (o Object) GetRequest(method string, body interface{}, args ...Params) (*ht
 	return o.Bucket.GetRequest(method, o.Name, body, args...)
 }
 
+// SignURL returns the signed URL given a method and the expires seconds.
+//
+// The first optional Params is for Header, the second is for Query.
+//
+// The headers which are signed must be sent with the same values when requesting the URL.
+//
+// Relevant documentation:
+//
+// https://docs.aliyun.com/#/pub/oss/api-reference/access-control&signature-url
+func (o Object) SignURL(method string, seconds int, args ...Params) (string, error) {
+	if seconds <= 0 {
+		return "", errExpiresInvalid
+	}
+	req, err := o.GetRequest(method, nil, args...)
+	if err != nil {
+		return "", err
+	}
+	o.Signature(req, seconds)
+	return req.URL.String(), nil
+}
+
 // Put the data as the object content,
-// also send the ACL if it is not the empty string,
+// also send the ACL and the storage class if they are not the empty string
+// and the tagging and the encryption if they are not empty,
 // returns the ETag.
 //
 // The first optional Params is for Header, the second is for Query.
@@ -78,9 +125,7 @@ func (o Object) Put(data interface{}, args ...Params) (string, error) {
 	}
 
 	header, query := getHeaderQuery(args)
-	if o.ACL != "" {
-		header.Set("x-oss-object-acl", o.ACL)
-	}
+	o.setPutHeader(header)
 
 	res, err := o.GetResponse("PUT", data, header, query)
 	if err != nil {
@@ -95,16 +140,34 @@ func (o Object) Put(data interface{}, args ...Params) (string, error) {
 	return res.Header.Get("ETag"), nil
 }
 
+// setPutHeader set the ACL, the storage class, the tagging and the encryption headers for upload.
+func (o Object) setPutHeader(header Params) {
+	if o.ACL != "" {
+		header.Set("x-oss-object-acl", o.ACL)
+	}
+	if o.StorageClass != "" {
+		header.Set("x-oss-storage-class", o.StorageClass)
+	}
+	if o.Tagging != nil && len(o.Tagging.TagSet.Tag) > 0 {
+		header.Set(HeaderTagging, o.Tagging.Encode())
+	}
+	o.Encryption.setHeader(header)
+}
+
 // Copy the object content from the source object,
-// also send the ACL if it is not the empty string.
+// also send the ACL and the storage class if they are not the empty string
+// and the encryption if it is not empty,
+// the tagging replaces the source object tagging if it is not empty.
 //
 // The first optional Params is for Header, the second is for Query.
 //
+// To change the storage class of an object copy it to itself with a different storage class.
+//
 // Relevant documentation:
 //
 // https://docs.aliyun.com/#/pub/oss/api-reference/object&CopyObject
 func (o Object) Copy(source Object, args ...Params) (*CopyObjectResult, error) {
-	s := source.FullName()
+	s := source.copySource()
 	if s == "" {
 		return nil, errSourceObjectInvalid
 	}
@@ -115,6 +178,14 @@ func (o Object) Copy(source Object, args ...Params) (*CopyObjectResult, error) {
 	if o.ACL != "" {
 		header.Set("x-oss-object-acl", o.ACL)
 	}
+	if o.StorageClass != "" {
+		header.Set("x-oss-storage-class", o.StorageClass)
+	}
+	if o.Tagging != nil && len(o.Tagging.TagSet.Tag) > 0 {
+		header.Set(HeaderTagging, o.Tagging.Encode())
+		header.Set("x-oss-tagging-directive", "Replace")
+	}
+	o.Encryption.setHeader(header)
 
 	v := new(CopyObjectResult)
 
@@ -140,7 +211,9 @@ func (o Object) Get(data interface{}, args ...Params) error {
 	if !isGetDataType(data) {
 		return errDataTypeNotSupported
 	}
-	return o.Do("GET", nil, data, args...)
+	header, query := getHeaderQuery(args)
+	o.setVersionId(query)
+	return o.Do("GET", nil, data, header, query)
 }
 
 // Range get the object range content to the data given the first-byte-pos and the length,
@@ -164,18 +237,24 @@ func (o Object) Range(first, length int64, data interface{}, args ...Params) (in
 	if !isGetDataType(data) {
 		return 0, 0, errDataTypeNotSupported
 	}
+	l, t, _, err := o.getRange(first, length, data, args...)
+	return l, t, err
+}
 
+// getRange is the same as the method Range but also returns the response header.
+func (o Object) getRange(first, length int64, data interface{}, args ...Params) (int64, int64, http.Header, error) {
 	r := FormatRange(first, length)
 	if r == "" {
-		return 0, 0, errRangeInvalid
+		return 0, 0, nil, errRangeInvalid
 	}
 
 	header, query := getHeaderQuery(args)
 	header.Set(HeaderRange, r)
+	o.setVersionId(query)
 
 	res, err := o.GetResponse("GET", nil, header, query)
 	if err != nil {
-		return 0, 0, err
+		return 0, 0, nil, err
 	}
 
 	err = newBody(res)
@@ -184,25 +263,25 @@ func (o Object) Range(first, length int64, data interface{}, args ...Params) (in
 		err = readError(res)
 	}
 	if err != nil {
-		return 0, 0, err
+		return 0, 0, nil, err
 	}
 
 	f, l, t, err := ParseContentRange(res.Header.Get(HeaderContentRange))
 	if err != nil {
-		return 0, 0, err
+		return 0, 0, nil, err
 	}
 
 	if f == -1 || l == -1 || t == -1 || f != first || (length > 0 && l > length) {
-		return 0, 0, ErrContentRangeCorrupt
+		return 0, 0, nil, ErrContentRangeCorrupt
 	}
 
 	if n, err := readBody(res.Body, data); err != nil {
-		return 0, 0, err
+		return 0, 0, nil, err
 	} else if n != l {
-		return 0, 0, ErrContentRangeCorrupt
+		return 0, 0, nil, ErrContentRangeCorrupt
 	}
 
-	return l, t, nil
+	return l, t, res.Header, nil
 }
 
 // Append the data to the object content, also send the ACL if it is not the empty string,
@@ -255,7 +334,9 @@ func (o Object) Append(position int64, data interface{}, args ...Params) (next i
 //
 // https://docs.aliyun.com/#/pub/oss/api-reference/object&DeleteObject
 func (o Object) Delete(args ...Params) error {
-	return o.Do("DELETE", nil, nil, args...)
+	header, query := getHeaderQuery(args)
+	o.setVersionId(query)
+	return o.Do("DELETE", nil, nil, header, query)
 }
 
 // Head the object and returns the response header.
@@ -266,7 +347,9 @@ func (o Object) Delete(args ...Params) error {
 //
 // https://docs.aliyun.com/#/pub/oss/api-reference/object&HeadObject
 func (o Object) Head(args ...Params) (http.Header, error) {
-	res, err := o.GetResponse("HEAD", nil, args...)
+	header, query := getHeaderQuery(args)
+	o.setVersionId(query)
+	res, err := o.GetResponse("HEAD", nil, header, query)
 	if err != nil {
 		return nil, err
 	}
@@ -277,6 +360,40 @@ func (o Object) Head(args ...Params) (http.Header, error) {
 	return res.Header, nil
 }
 
+// GetStorageClass returns the object storage class by the method Head.
+//
+// The first optional Params is for Header, the second is for Query.
+//
+// Get and record:
+//  o.StorageClass, err = o.GetStorageClass()
+//
+// Relevant documentation:
+//
+// https://docs.aliyun.com/#/pub/oss/api-reference/object&HeadObject
+func (o Object) GetStorageClass(args ...Params) (string, error) {
+	h, err := o.Head(args...)
+	if err != nil {
+		return "", err
+	}
+	return h.Get("x-oss-storage-class"), nil
+}
+
+// GetType returns the object type by the method Head,
+// Normal, Appendable, Multipart or Symlink.
+//
+// The first optional Params is for Header, the second is for Query.
+//
+// Relevant documentation:
+//
+// https://docs.aliyun.com/#/pub/oss/api-reference/object&HeadObject
+func (o Object) GetType(args ...Params) (string, error) {
+	h, err := o.Head(args...)
+	if err != nil {
+		return "", err
+	}
+	return h.Get("x-oss-object-type"), nil
+}
+
 // GetInfo returns the object info.
 //
 // The first optional Params is for Header, the second is for Query.
@@ -306,6 +423,11 @@ type GetObjectInfoResult struct {
 	LastModified string // 2006-01-02T15:04:05.000Z
 }
 
+// IsSymlink returns true if the object is a symlink.
+func (v GetObjectInfoResult) IsSymlink() bool {
+	return v.Type == ObjectTypeSymlink
+}
+
 // PutACL change the object acl if it is the empty string.
 //
 // The first optional Params is for Header, the second is for Query.
@@ -316,6 +438,7 @@ type GetObjectInfoResult struct {
 func (o Object) PutACL(args ...Params) error {
 	header, query := getHeaderQuery(args)
 	query.Set("acl", "")
+	o.setVersionId(query)
 	if o.ACL != "" {
 		header.Set("x-oss-object-acl", o.ACL)
 	}
@@ -335,6 +458,7 @@ func (o Object) PutACL(args ...Params) error {
 func (o Object) GetACL(args ...Params) (string, error) {
 	header, query := getHeaderQuery(args)
 	query.Set("acl", "")
+	o.setVersionId(query)
 	var acp AccessControlPolicy
 	err := o.Do("GET", nil, &acp, header, query)
 	return acp.AccessControlList.Grant, err
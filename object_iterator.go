@@ -0,0 +1,89 @@
+// Copyright 2015 Chen Xianren. All rights reserved.
+
+package oss
+
+import "net/url"
+
+// ObjectIterator iterates the pages of the objects, for example:
+//
+//	it := b.NewObjectIterator(nil, Params{"prefix": {"photos/"}, "delimiter": {"/"}})
+//	for it.Next() {
+//		v := it.Result()
+//		// ...
+//	}
+//	if err := it.Err(); err != nil {
+//		// ...
+//	}
+//
+// The objects are listed with the encoding-type url and the Result is decoded,
+// so the keys with the characters such as the control characters are supported.
+type ObjectIterator struct {
+	b      Bucket
+	header Params
+	query  Params
+	result *ListBucketResult
+	err    error
+	done   bool
+}
+
+// NewObjectIterator returns a new ObjectIterator of the bucket,
+// the args are the same as the method ListObject and are not changed.
+func (b Bucket) NewObjectIterator(args ...Params) *ObjectIterator {
+	header, query := getHeaderQuery(args)
+	it := &ObjectIterator{b: b, header: Params{}, query: Params{}}
+	it.header.Copy(header)
+	it.query.Copy(query)
+	it.query.Set("encoding-type", "url")
+	return it
+}
+
+// Next gets the next page, returns false if no more pages or an error occurred.
+func (it *ObjectIterator) Next() bool {
+	if it.done || it.err != nil {
+		return false
+	}
+	it.result, it.err = it.b.ListObject(it.header, it.query)
+	if it.err == nil {
+		it.err = it.result.decode()
+	}
+	if it.err != nil {
+		return false
+	}
+	if it.result.IsTruncated {
+		it.query.Set("marker", it.result.NextMarker)
+	} else {
+		it.done = true
+	}
+	return true
+}
+
+// Result returns the current page.
+func (it *ObjectIterator) Result() *ListBucketResult {
+	return it.result
+}
+
+// Err returns the error occurred when get the next page.
+func (it *ObjectIterator) Err() error {
+	return it.err
+}
+
+// decode the URL encoded fields of the result listed with the encoding-type url.
+func (v *ListBucketResult) decode() error {
+	var err error
+	unescape := func(s *string) {
+		if err == nil {
+			*s, err = url.PathUnescape(*s)
+		}
+	}
+	unescape(&v.Prefix)
+	unescape(&v.Marker)
+	unescape(&v.Delimiter)
+	unescape(&v.NextMarker)
+	for i := range v.Contents {
+		unescape(&v.Contents[i].Key)
+	}
+	for i := range v.CommonPrefixes {
+		unescape(&v.CommonPrefixes[i].Prefix)
+	}
+	return err
+}
@@ -0,0 +1,34 @@
+// Copyright 2015 Chen Xianren. All rights reserved.
+
+package oss
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestObjectIterator(t *testing.T) {
+	f, b := newFakeOSS(t)
+	for _, k := range []string{"a/1", "a/2\x01", "a/b c/3", "a/d/4", "a/e", "b"} {
+		f.put(k, HelloWorld)
+	}
+
+	query := Params{"prefix": {"a/"}, "delimiter": {"/"}, "max-keys": {"2"}}
+	it := b.NewObjectIterator(nil, query)
+	var keys, prefixes []string
+	pages := 0
+	for it.Next() {
+		pages++
+		for _, i := range it.Result().Contents {
+			keys = append(keys, i.Key)
+		}
+		for _, i := range it.Result().CommonPrefixes {
+			prefixes = append(prefixes, i.Prefix)
+		}
+	}
+	fatal(t, it.Err())
+	equal(t, "pages", 3, pages)
+	equal(t, "keys", true, reflect.DeepEqual([]string{"a/1", "a/2\x01", "a/e"}, keys))
+	equal(t, "prefixes", true, reflect.DeepEqual([]string{"a/b c/", "a/d/"}, prefixes))
+	equal(t, "query unchanged", 3, len(query))
+}
@@ -454,3 +454,159 @@ func TestMultipartUpload(t *testing.T) {
 
 	fatal(t, o.Bucket.Delete())
 }
+
+func TestMultipartCopy(t *testing.T) {
+	src := newObject()
+	dst := Object{
+		Bucket: src.Bucket,
+		Name:   "copy-" + src.Name,
+	}
+
+	fatal(t, src.Bucket.Put())
+
+	const n = 2*MultipartCopyMinPartSize + 2
+	data := make([]byte, n)
+	copy(data, HelloWorld)
+
+	header := Params{}
+	header.Set("x-oss-meta-hello", "world")
+
+	etag, err := src.Put(data, header)
+	fatal(t, err)
+	if etag == "" {
+		t.Fatal("expected ETag")
+	}
+
+	cmur, err := dst.MultipartCopy(src, MultipartCopyMinPartSize, 2)
+	fatal(t, err)
+	if cmur.ETag == "" {
+		t.Fatal("expected ETag")
+	}
+
+	h, err := dst.Head()
+	fatal(t, err)
+	equal(t, "head meta", "world", h.Get("x-oss-meta-hello"))
+
+	var v []byte
+	fatal(t, dst.Get(&v))
+
+	if !bytes.Equal(data, v) {
+		t.Fatal("expected Equal")
+	}
+
+	header = Params{}
+	header.Set("x-oss-metadata-directive", "REPLACE")
+	header.Set("x-oss-meta-hello", "oss")
+
+	_, err = dst.MultipartCopy(src, 0, 0, header)
+	fatal(t, err)
+	equal(t, "header unchanged", "REPLACE", header.Get("x-oss-metadata-directive"))
+	equal(t, "header unchanged", 2, len(header))
+
+	h, err = dst.Head()
+	fatal(t, err)
+	equal(t, "head meta", "oss", h.Get("x-oss-meta-hello"))
+
+	fatal(t, src.Delete())
+	fatal(t, dst.Delete())
+
+	fatal(t, src.Bucket.Delete())
+}
+
+func TestObjectStorageClass(t *testing.T) {
+	o := newObject()
+
+	fatal(t, o.Bucket.Put())
+
+	o.StorageClass = StorageIA
+	_, err := o.Put([]byte(HelloWorld))
+	fatal(t, err)
+
+	o.StorageClass = ""
+	o.StorageClass, err = o.GetStorageClass()
+	fatal(t, err)
+	equal(t, "storage class", StorageIA, o.StorageClass)
+
+	o.StorageClass = StorageStandard
+	_, err = o.Copy(o)
+	fatal(t, err)
+
+	o.StorageClass, err = o.GetStorageClass()
+	fatal(t, err)
+	equal(t, "storage class", StorageStandard, o.StorageClass)
+
+	fatal(t, o.Delete())
+
+	fatal(t, o.Bucket.Delete())
+}
+
+func TestObjectSymlinkTarget(t *testing.T) {
+	f, b := newFakeOSS(t)
+	targets := map[string]string{}
+	f.before = func(w http.ResponseWriter, r *http.Request) bool {
+		if _, ok := r.URL.Query()["symlink"]; !ok {
+			return false
+		}
+		if r.Method == "PUT" {
+			targets[r.URL.Path] = r.Header.Get(HeaderSymlinkTarget)
+		} else {
+			w.Header().Set(HeaderSymlinkTarget, targets[r.URL.Path])
+		}
+		return true
+	}
+
+	l := Object{Bucket: b, Name: "link"}
+	fatal(t, l.PutSymlink("a b/c+d.txt"))
+	equal(t, "escaped target", "a%20b%2Fc%2Bd.txt", targets["/link"])
+
+	target, err := l.GetSymlink()
+	fatal(t, err)
+	equal(t, "symlink target", "a b/c+d.txt", target)
+}
+
+func TestObjectSymlink(t *testing.T) {
+	o := newObject()
+	l := Object{
+		Bucket: o.Bucket,
+		Name:   "symlink-" + o.Name,
+	}
+
+	fatal(t, o.Bucket.Put())
+
+	_, err := o.Put([]byte(HelloWorld))
+	fatal(t, err)
+
+	_, err = o.GetSymlink()
+	e, ok := err.(Error)
+	if !(ok && e.Code == "NotSymlink") {
+		t.Fatal("expected NotSymlink")
+	}
+
+	header := Params{}
+	header.Set("x-oss-meta-hello", "world")
+	fatal(t, l.PutSymlink(o.Name, header))
+
+	target, err := l.GetSymlink()
+	fatal(t, err)
+	equal(t, "symlink target", o.Name, target)
+
+	typ, err := l.GetType()
+	fatal(t, err)
+	equal(t, "object type", ObjectTypeSymlink, typ)
+
+	h, err := l.Head()
+	fatal(t, err)
+	equal(t, "head meta", "world", h.Get("x-oss-meta-hello"))
+
+	var v []byte
+	fatal(t, l.Get(&v))
+
+	if HelloWorld != string(v) {
+		t.Fatal("expected HelloWorld")
+	}
+
+	fatal(t, l.Delete())
+	fatal(t, o.Delete())
+
+	fatal(t, o.Bucket.Delete())
+}
@@ -25,6 +25,21 @@ const (
 	ACLPrivate         = "private"
 )
 
+// OSS Storage Class List
+const (
+	StorageStandard = "Standard"
+	StorageIA       = "IA"
+	StorageArchive  = "Archive"
+)
+
+// OSS Object Type List
+const (
+	ObjectTypeNormal     = "Normal"
+	ObjectTypeAppendable = "Appendable"
+	ObjectTypeMultipart  = "Multipart"
+	ObjectTypeSymlink    = "Symlink"
+)
+
 // OSS Location List
 const (
 	LocationCNQingdao    = "oss-cn-qingdao"
@@ -202,18 +217,30 @@ func CanonicalizedHeaders(header http.Header) string {
 var resources = []string{
 	"acl",
 	"append",
+	"bucketInfo",
+	"callback",
+	"callback-var",
+	"comp",
 	"cors",
 	"delete",
+	"encryption",
+	"endTime",
 	"group",
 	"lifecycle",
 	"link",
+	"live",
 	"location",
 	"logging",
 	"objectInfo",
 	"partNumber",
+	"policy",
 	"position",
 	"qos",
 	"referer",
+	"replication",
+	"replicationLocation",
+	"replicationProgress",
+	"resourceGroup",
 	"response-cache-control",
 	"response-content-disposition",
 	"response-content-encoding",
@@ -222,9 +249,22 @@ var resources = []string{
 	"response-expires",
 	"restore",
 	"security-token",
+	"startTime",
+	"stat",
+	"status",
+	"symlink",
+	"tagging",
 	"uploadId",
 	"uploads",
+	"versionId",
+	"versioning",
+	"versions",
+	"vod",
 	"website",
+	"worm",
+	"wormExtend",
+	"wormId",
+	"x-oss-process",
 }
 
 // CanonicalizedResource returns the canonicalized OSS resource as a string.
@@ -368,6 +408,14 @@ func (e Error) Error() string {
 	return fmt.Sprintf("Code: %s, RequestId: %s, HostId: %s, Message: %s", e.Code, e.RequestId, e.HostId, e.Message)
 }
 
+// ErrorCode returns the Code if the err is the Error, otherwise returns the empty string.
+func ErrorCode(err error) string {
+	if e, ok := err.(Error); ok {
+		return e.Code
+	}
+	return ""
+}
+
 // Owner contains the information of the bucket owner.
 type Owner struct {
 	ID          string
@@ -5,6 +5,7 @@ package oss
 import (
 	"os"
 	"runtime"
+	"sort"
 	"strconv"
 	"testing"
 )
@@ -32,6 +33,10 @@ func fatal(t *testing.T, err error) {
 	}
 }
 
+func TestResourcesSorted(t *testing.T) {
+	equal(t, "resources sorted", true, sort.StringsAreSorted(resources))
+}
+
 func newService() Service {
 	return Service{
 		Unsafe:          os.Getenv("OSSTestUnsafe") == "true",
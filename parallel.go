@@ -0,0 +1,87 @@
+// Copyright 2015 Chen Xianren. All rights reserved.
+
+package oss
+
+import "sync"
+
+// parallel calls the fn with the indexes from 0 to n-1, at most routines calls at the same time,
+// stops calling after the first error and returns it when all the started calls are returned.
+func parallel(n, routines int, fn func(i int) error) error {
+	if routines <= 0 {
+		routines = 1
+	}
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs error
+	)
+
+	sem := make(chan struct{}, routines)
+	for i := 0; i < n; i++ {
+		sem <- struct{}{}
+		mu.Lock()
+		failed := errs != nil
+		mu.Unlock()
+		if failed {
+			<-sem
+			break
+		}
+
+		wg.Add(1)
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			if err := fn(i); err != nil {
+				mu.Lock()
+				if errs == nil {
+					errs = err
+				}
+				mu.Unlock()
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	return errs
+}
+
+// multipartUpload initiates a Multipart Upload, sends the n parts by the part in parallel
+// which returns the ETag given the partNumber and the uploadId, then completes it,
+// the Multipart Upload is aborted if any part failed or the complete failed.
+//
+// The first optional Params is for Header, the second is for Query,
+// both are sent with the InitiateMultipartUpload.
+func (o Object) multipartUpload(n, routines int, part func(partNumber int, uploadId string) (string, error), args ...Params) (*CompleteMultipartUploadResult, error) {
+	imu, err := o.InitiateMultipartUpload(args...)
+	if err != nil {
+		return nil, err
+	}
+
+	cmu := CompleteMultipartUpload{
+		Part: make([]CompleteMultipartUploadPart, n),
+	}
+
+	err = parallel(n, routines, func(i int) error {
+		etag, err := part(i+1, imu.UploadId)
+		if err == nil {
+			cmu.Part[i] = CompleteMultipartUploadPart{i + 1, etag}
+		}
+		return err
+	})
+	if err != nil {
+		o.AbortMultipartUpload(imu.UploadId)
+		return nil, err
+	}
+
+	v, err := o.CompleteMultipartUpload(imu.UploadId, cmu)
+	if err != nil {
+		o.AbortMultipartUpload(imu.UploadId)
+		return nil, err
+	}
+
+	return v, nil
+}
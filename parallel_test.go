@@ -0,0 +1,30 @@
+// Copyright 2015 Chen Xianren. All rights reserved.
+
+package oss
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestParallel(t *testing.T) {
+	done := make([]bool, 10)
+	fatal(t, parallel(len(done), 3, func(i int) error {
+		done[i] = true
+		return nil
+	}))
+	for i, v := range done {
+		equal(t, strconv.Itoa(i), true, v)
+	}
+
+	calls := 0
+	err := parallel(100, 1, func(i int) error {
+		calls++
+		if i >= 5 {
+			return errPrefixRequired
+		}
+		return nil
+	})
+	equal(t, "first error", errPrefixRequired, err)
+	equal(t, "stopped", 6, calls)
+}
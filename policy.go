@@ -0,0 +1,188 @@
+// Copyright 2015 Chen Xianren. All rights reserved.
+
+package oss
+
+import (
+	"encoding/json"
+)
+
+// OSS Policy Effect List
+const (
+	PolicyAllow = "Allow"
+	PolicyDeny  = "Deny"
+)
+
+// OSS Policy Condition Keys
+const (
+	PolicySourceIp        = "acs:SourceIp"
+	PolicyReferer         = "acs:Referer"
+	PolicySecureTransport = "acs:SecureTransport"
+	PolicyPrefix          = "oss:Prefix"
+	PolicyDelimiter       = "oss:Delimiter"
+)
+
+// PutPolicy set the bucket policy, replace it if already exists.
+//
+// The first optional Params is for Header, the second is for Query.
+//
+// Relevant documentation:
+//
+// https://help.aliyun.com/document_detail/100680.html
+func (b Bucket) PutPolicy(policy Policy, args ...Params) error {
+	body, err := json.Marshal(policy)
+	if err != nil {
+		return err
+	}
+	header, query := getHeaderQuery(args)
+	query.Set("policy", "")
+	return b.Do("PUT", "", body, nil, header, query)
+}
+
+// GetPolicy returns the bucket policy.
+//
+// The first optional Params is for Header, the second is for Query.
+//
+// Relevant documentation:
+//
+// https://help.aliyun.com/document_detail/100680.html
+func (b Bucket) GetPolicy(args ...Params) (*Policy, error) {
+	header, query := getHeaderQuery(args)
+	query.Set("policy", "")
+
+	var body []byte
+
+	err := b.Do("GET", "", nil, &body, header, query)
+	if err != nil {
+		return nil, err
+	}
+
+	v := new(Policy)
+
+	err = json.Unmarshal(body, v)
+	if err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+// DeletePolicy remove the bucket policy.
+//
+// The first optional Params is for Header, the second is for Query.
+//
+// Relevant documentation:
+//
+// https://help.aliyun.com/document_detail/100680.html
+func (b Bucket) DeletePolicy(args ...Params) error {
+	header, query := getHeaderQuery(args)
+	query.Set("policy", "")
+	return b.Do("DELETE", "", nil, nil, header, query)
+}
+
+// PolicyResource returns the policy resource of the bucket and the object name pattern,
+// such as acs:oss:*:*:bucket/prefix/*, the bucket itself if the pattern is the empty string.
+func (b Bucket) PolicyResource(pattern string) string {
+	s := "acs:oss:*:*:" + b.Name
+	if pattern != "" {
+		s += "/" + pattern
+	}
+	return s
+}
+
+// PolicyStrings represents the strings of the policy,
+// it is decoded from a JSON string or an array of strings.
+type PolicyStrings []string
+
+// UnmarshalJSON decodes a JSON string or an array of strings.
+func (v *PolicyStrings) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		*v = PolicyStrings{s}
+		return nil
+	}
+	var a []string
+	if err := json.Unmarshal(b, &a); err != nil {
+		return err
+	}
+	*v = a
+	return nil
+}
+
+// PolicyStatement represents a statement of the policy.
+//
+// The Condition maps the condition operator to the condition keys and values, for example:
+//
+//	{"IpAddress": {"acs:SourceIp": ["192.168.0.0/16"]}}
+type PolicyStatement struct {
+	Effect    string                              // Allow, Deny
+	Principal PolicyStrings                       `json:",omitempty"`
+	Action    PolicyStrings                       `json:",omitempty"`
+	Resource  PolicyStrings                       `json:",omitempty"`
+	Condition map[string]map[string]PolicyStrings `json:",omitempty"`
+}
+
+// NewPolicyStatement returns a new PolicyStatement given the effect.
+func NewPolicyStatement(effect string) *PolicyStatement {
+	return &PolicyStatement{Effect: effect}
+}
+
+// Principals appends the principals, the RAM user or account ids, * for anonymous.
+func (s *PolicyStatement) Principals(principals ...string) *PolicyStatement {
+	s.Principal = append(s.Principal, principals...)
+	return s
+}
+
+// Actions appends the actions, such as oss:GetObject, oss:*.
+func (s *PolicyStatement) Actions(actions ...string) *PolicyStatement {
+	s.Action = append(s.Action, actions...)
+	return s
+}
+
+// Resources appends the resources, see the method Bucket.PolicyResource.
+func (s *PolicyStatement) Resources(resources ...string) *PolicyStatement {
+	s.Resource = append(s.Resource, resources...)
+	return s
+}
+
+// When appends the condition values given the operator and the key.
+func (s *PolicyStatement) When(operator, key string, values ...string) *PolicyStatement {
+	if s.Condition == nil {
+		s.Condition = make(map[string]map[string]PolicyStrings)
+	}
+	m := s.Condition[operator]
+	if m == nil {
+		m = make(map[string]PolicyStrings)
+		s.Condition[operator] = m
+	}
+	m[key] = append(m[key], values...)
+	return s
+}
+
+// SourceIp appends the IpAddress condition of the source IPs or CIDRs.
+func (s *PolicyStatement) SourceIp(ips ...string) *PolicyStatement {
+	return s.When("IpAddress", PolicySourceIp, ips...)
+}
+
+// Referer appends the StringLike condition of the referers, the wildcards * and ? are supported.
+func (s *PolicyStatement) Referer(referers ...string) *PolicyStatement {
+	return s.When("StringLike", PolicyReferer, referers...)
+}
+
+// Policy represents the bucket policy, the RAM JSON policy.
+//
+// Relevant documentation:
+//
+// https://help.aliyun.com/document_detail/100680.html
+type Policy struct {
+	Version   string
+	Statement []PolicyStatement
+}
+
+// NewPolicy returns a new Policy of version 1 given the statements.
+func NewPolicy(statements ...*PolicyStatement) Policy {
+	v := Policy{Version: "1"}
+	for _, i := range statements {
+		v.Statement = append(v.Statement, *i)
+	}
+	return v
+}
@@ -0,0 +1,62 @@
+// Copyright 2015 Chen Xianren. All rights reserved.
+
+package oss
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestPolicyJSON(t *testing.T) {
+	b := Bucket{Name: "oss-example"}
+
+	p := NewPolicy(
+		NewPolicyStatement(PolicyAllow).
+			Principals("*").
+			Actions("oss:GetObject").
+			Resources(b.PolicyResource("public/*")).
+			SourceIp("192.168.0.0/16", "10.0.0.1").
+			Referer("http://*.cxr29.com"),
+	)
+
+	v, err := json.Marshal(p)
+	fatal(t, err)
+	equal(t, "JSON", `{"Version":"1","Statement":[{"Effect":"Allow","Principal":["*"],"Action":["oss:GetObject"],"Resource":["acs:oss:*:*:oss-example/public/*"],"Condition":{"IpAddress":{"acs:SourceIp":["192.168.0.0/16","10.0.0.1"]},"StringLike":{"acs:Referer":["http://*.cxr29.com"]}}}]}`, string(v))
+
+	var x Policy
+	fatal(t, json.Unmarshal([]byte(`{"Version":"1","Statement":[{"Effect":"Deny","Action":"oss:*","Resource":["acs:oss:*:*:oss-example"],"Condition":{"Bool":{"acs:SecureTransport":"false"}}}]}`), &x))
+	equal(t, "Statement", 1, len(x.Statement))
+	equal(t, "Action", "oss:*", x.Statement[0].Action[0])
+	equal(t, "Condition", "false", x.Statement[0].Condition["Bool"][PolicySecureTransport][0])
+}
+
+func TestBucketPolicy(t *testing.T) {
+	b := newBucket()
+
+	fatal(t, b.Put())
+
+	p := NewPolicy(
+		NewPolicyStatement(PolicyAllow).
+			Principals("*").
+			Actions("oss:GetObject").
+			Resources(b.PolicyResource("*")).
+			Referer("http://www.cxr29.com/*"),
+	)
+	fatal(t, b.PutPolicy(p))
+
+	v, err := b.GetPolicy()
+	fatal(t, err)
+	equal(t, "Statement", 1, len(v.Statement))
+	equal(t, "Effect", PolicyAllow, v.Statement[0].Effect)
+	equal(t, "Resource", b.PolicyResource("*"), v.Statement[0].Resource[0])
+
+	fatal(t, b.DeletePolicy())
+
+	_, err = b.GetPolicy()
+	e, ok := err.(Error)
+	if !(ok && e.Code == "NoSuchBucketPolicy") {
+		t.Fatal("expected NoSuchBucketPolicy")
+	}
+
+	fatal(t, b.Delete())
+}
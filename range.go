@@ -10,8 +10,9 @@ import (
 
 //
 const (
-	HeaderRange        = "Range"
-	HeaderContentRange = "Content-Range"
+	HeaderRange           = "Range"
+	HeaderContentRange    = "Content-Range"
+	HeaderCopySourceRange = "x-oss-copy-source-range"
 )
 
 //
@@ -0,0 +1,271 @@
+// Copyright 2015 Chen Xianren. All rights reserved.
+
+package oss
+
+import (
+	"container/list"
+	"errors"
+	"io"
+	"strconv"
+	"sync"
+)
+
+// Object reader defaults
+const (
+	ObjectReaderBlockSize   = 1 << 20 // 1M
+	ObjectReaderCacheBlocks = 8
+)
+
+var (
+	errReaderClosed  = errors.New("object reader closed")
+	errOffsetInvalid = errors.New("offset invalid")
+)
+
+// ObjectReaderOptions represents the ObjectReader options.
+//
+// The object is read by the method Range block by block,
+// the recently used blocks are cached and the Read reads the next ReadAhead blocks in background.
+type ObjectReaderOptions struct {
+	BlockSize   int64 // the ObjectReaderBlockSize is used if <= 0
+	CacheBlocks int   // the ObjectReaderCacheBlocks is used if <= 0, at least ReadAhead+2
+	ReadAhead   int   // no read-ahead if <= 0
+}
+
+// ObjectReader implements the io.Reader, io.Seeker, io.ReaderAt and io.Closer of the object.
+//
+// The object size and ETag are got when created, the blocks are read with the If-Match ETag,
+// so returns PreconditionFailed Error if the object is changed.
+//
+// The ReadAt is safe for concurrent use, the Read and the Seek share the offset.
+type ObjectReader struct {
+	o      Object
+	header Params
+	query  Params
+	opt    ObjectReaderOptions
+	size   int64
+	etag   string
+
+	mu     sync.Mutex
+	offset int64
+	blocks map[int64]*readerBlock
+	lru    *list.List // the front is the most recently used
+	closed bool
+}
+
+type readerBlock struct {
+	index int64
+	elem  *list.Element
+	done  chan struct{}
+	data  []byte
+	err   error
+}
+
+// NewReader returns the ObjectReader of the object.
+//
+// The first optional Params is for Header, the second is for Query,
+// both are sent with the Head and all the Range.
+func (o Object) NewReader(opt ObjectReaderOptions, args ...Params) (*ObjectReader, error) {
+	if opt.BlockSize <= 0 {
+		opt.BlockSize = ObjectReaderBlockSize
+	}
+	if opt.ReadAhead < 0 {
+		opt.ReadAhead = 0
+	}
+	if opt.CacheBlocks <= 0 {
+		opt.CacheBlocks = ObjectReaderCacheBlocks
+	}
+	if opt.CacheBlocks < opt.ReadAhead+2 {
+		opt.CacheBlocks = opt.ReadAhead + 2
+	}
+
+	header, query := getHeaderQuery(args)
+	r := &ObjectReader{
+		o:      o,
+		header: Params{},
+		query:  Params{},
+		opt:    opt,
+		blocks: map[int64]*readerBlock{},
+		lru:    list.New(),
+	}
+	r.header.Copy(header)
+	r.query.Copy(query)
+
+	h, err := o.Head(r.args())
+	if err != nil {
+		return nil, err
+	}
+	if r.size, err = strconv.ParseInt(h.Get("Content-Length"), 10, 64); err != nil {
+		return nil, err
+	}
+	r.etag = h.Get("ETag")
+
+	return r, nil
+}
+
+// args returns the copies of the Header and the Query for a request.
+func (r *ObjectReader) args() (Params, Params) {
+	header, query := Params{}, Params{}
+	header.Copy(r.header)
+	query.Copy(r.query)
+	return header, query
+}
+
+// Size returns the object size.
+func (r *ObjectReader) Size() int64 {
+	return r.size
+}
+
+// block returns the block given an index, reads it if not cached,
+// and waits for it if wait is true.
+func (r *ObjectReader) block(index int64, wait bool) (*readerBlock, error) {
+	r.mu.Lock()
+	if r.closed {
+		r.mu.Unlock()
+		return nil, errReaderClosed
+	}
+	b, ok := r.blocks[index]
+	if ok {
+		r.lru.MoveToFront(b.elem)
+		r.mu.Unlock()
+	} else {
+		b = &readerBlock{index: index, done: make(chan struct{})}
+		b.elem = r.lru.PushFront(b)
+		r.blocks[index] = b
+		for r.lru.Len() > r.opt.CacheBlocks {
+			r.remove(r.lru.Back().Value.(*readerBlock))
+		}
+		r.mu.Unlock()
+
+		if wait {
+			r.read(b)
+		} else {
+			go r.read(b)
+		}
+	}
+
+	if wait {
+		<-b.done
+		return b, b.err
+	}
+	return b, nil
+}
+
+// remove the block from the cache, must be called with the mu locked.
+func (r *ObjectReader) remove(b *readerBlock) {
+	if r.blocks[b.index] == b {
+		delete(r.blocks, b.index)
+		r.lru.Remove(b.elem)
+	}
+}
+
+// read the block by the method Range, removes it from the cache if failed.
+func (r *ObjectReader) read(b *readerBlock) {
+	defer close(b.done)
+
+	first := b.index * r.opt.BlockSize
+	length := r.opt.BlockSize
+	if first+length > r.size {
+		length = r.size - first
+	}
+
+	header, query := r.args()
+	if r.etag != "" {
+		header.Set("If-Match", r.etag)
+	}
+	_, _, b.err = r.o.Range(first, length, &b.data, header, query)
+	if b.err == nil && int64(len(b.data)) != length {
+		b.err = ErrContentRangeCorrupt
+	}
+
+	if b.err != nil {
+		r.mu.Lock()
+		r.remove(b)
+		r.mu.Unlock()
+	}
+}
+
+// ReadAt implements the io.ReaderAt.
+func (r *ObjectReader) ReadAt(p []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, errOffsetInvalid
+	}
+	n := 0
+	for n < len(p) {
+		if off >= r.size {
+			return n, io.EOF
+		}
+		b, err := r.block(off/r.opt.BlockSize, true)
+		if err != nil {
+			return n, err
+		}
+		i := copy(p[n:], b.data[off-b.index*r.opt.BlockSize:])
+		n += i
+		off += int64(i)
+	}
+	return n, nil
+}
+
+// Read implements the io.Reader, and reads the block of the offset and the next ReadAhead blocks in background.
+func (r *ObjectReader) Read(p []byte) (int, error) {
+	r.mu.Lock()
+	off := r.offset
+	r.mu.Unlock()
+
+	if off >= r.size {
+		return 0, io.EOF
+	}
+	if int64(len(p)) > r.size-off {
+		p = p[:r.size-off]
+	}
+
+	n, err := r.ReadAt(p, off)
+
+	r.mu.Lock()
+	r.offset = off + int64(n)
+	r.mu.Unlock()
+
+	if err == nil && r.opt.ReadAhead > 0 {
+		i := (off + int64(n)) / r.opt.BlockSize
+		last := (r.size - 1) / r.opt.BlockSize
+		for j := i; j <= i+int64(r.opt.ReadAhead) && j <= last; j++ {
+			r.block(j, false)
+		}
+	}
+	return n, err
+}
+
+// Seek implements the io.Seeker.
+func (r *ObjectReader) Seek(offset int64, whence int) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		return 0, errReaderClosed
+	}
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += r.offset
+	case io.SeekEnd:
+		offset += r.size
+	default:
+		return 0, errSeekInvalid
+	}
+	if offset < 0 {
+		return 0, errSeekInvalid
+	}
+	r.offset = offset
+	return offset, nil
+}
+
+// Close implements the io.Closer, drops the cached blocks.
+func (r *ObjectReader) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		return errReaderClosed
+	}
+	r.closed = true
+	r.blocks = nil
+	r.lru.Init()
+	return nil
+}
@@ -0,0 +1,97 @@
+// Copyright 2015 Chen Xianren. All rights reserved.
+
+package oss
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"io/ioutil"
+	"sync"
+	"testing"
+)
+
+func TestObjectReader(t *testing.T) {
+	f, b := newFakeOSS(t)
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, name := range []string{"a.txt", "b.txt", "c.txt"} {
+		w, err := zw.Create(name)
+		fatal(t, err)
+		_, err = w.Write(bytes.Repeat([]byte(name), 1000))
+		fatal(t, err)
+	}
+	fatal(t, zw.Close())
+	data := buf.Bytes()
+	f.put("archive.zip", string(data))
+
+	o := b.NewObject("archive.zip")
+	r, err := o.NewReader(ObjectReaderOptions{BlockSize: 100, CacheBlocks: 4, ReadAhead: 2})
+	fatal(t, err)
+	equal(t, "size", int64(len(data)), r.Size())
+
+	v, err := ioutil.ReadAll(r)
+	fatal(t, err)
+	equal(t, "read all", true, bytes.Equal(data, v))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			off := int64(i * len(data) / 8)
+			p := make([]byte, 150)
+			n, err := r.ReadAt(p, off)
+			if err != nil && err != io.EOF {
+				t.Error(err)
+				return
+			}
+			if !bytes.Equal(data[off:off+int64(n)], p[:n]) {
+				t.Error("read at", off)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	n, err := r.Seek(-10, io.SeekEnd)
+	fatal(t, err)
+	equal(t, "seek", int64(len(data)-10), n)
+	v, err = ioutil.ReadAll(r)
+	fatal(t, err)
+	equal(t, "read tail", string(data[len(data)-10:]), string(v))
+
+	zr, err := zip.NewReader(r, r.Size())
+	fatal(t, err)
+	equal(t, "zip files", 3, len(zr.File))
+	rc, err := zr.File[1].Open()
+	fatal(t, err)
+	v, err = ioutil.ReadAll(rc)
+	rc.Close()
+	fatal(t, err)
+	equal(t, "zip file", string(bytes.Repeat([]byte("b.txt"), 1000)), string(v))
+	fatal(t, r.Close())
+
+	r, err = o.NewReader(ObjectReaderOptions{BlockSize: 100})
+	fatal(t, err)
+	p := make([]byte, 10)
+	_, err = r.ReadAt(p, 0)
+	fatal(t, err)
+	f.mu.Lock()
+	gets := f.gets
+	f.mu.Unlock()
+	_, err = r.ReadAt(p, 50)
+	fatal(t, err)
+	f.mu.Lock()
+	equal(t, "cached", gets, f.gets)
+	f.mu.Unlock()
+
+	f.put("archive.zip", "changed")
+	_, err = r.ReadAt(p, 200)
+	if e, ok := err.(Error); !ok || e.Code[:3] != "412" {
+		t.Fatal("want precondition failed, got", err)
+	}
+	fatal(t, r.Close())
+	_, err = r.ReadAt(p, 0)
+	equal(t, "closed", errReaderClosed, err)
+}
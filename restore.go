@@ -0,0 +1,150 @@
+// Copyright 2015 Chen Xianren. All rights reserved.
+
+package oss
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// HeaderRestore is the response header of the archive object restore status.
+const HeaderRestore = "x-oss-restore"
+
+var (
+	errRestoreInvalid      = errors.New("restore status invalid")
+	errRestoreNotRequested = errors.New("restore not requested")
+)
+
+// RestoreStatus represents the archive object restore status.
+type RestoreStatus struct {
+	Ongoing    bool      // true if the restore is in progress
+	ExpiryDate time.Time // the restored copy expiry date, zero if ongoing
+}
+
+// ParseRestore parse the x-oss-restore header, for example:
+//
+//	ongoing-request="true"
+//	ongoing-request="false", expiry-date="Sun, 16 Apr 2017 08:12:33 GMT"
+func ParseRestore(s string) (*RestoreStatus, error) {
+	v := new(RestoreStatus)
+	ok := false
+	for s = strings.TrimSpace(s); s != ""; s = strings.TrimLeft(s, ", ") {
+		n := strings.Index(s, "=")
+		if n == -1 {
+			return nil, errRestoreInvalid
+		}
+		key := strings.ToLower(strings.TrimSpace(s[:n]))
+		s = strings.TrimSpace(s[n+1:])
+
+		var value string
+		if strings.HasPrefix(s, `"`) { // quoted value may contain the comma
+			n = strings.Index(s[1:], `"`)
+			if n == -1 {
+				return nil, errRestoreInvalid
+			}
+			value, s = s[1:n+1], s[n+2:]
+		} else {
+			if n = strings.Index(s, ","); n == -1 {
+				n = len(s)
+			}
+			value, s = s[:n], s[n:]
+		}
+
+		switch key {
+		case "ongoing-request":
+			switch value {
+			case "true":
+				v.Ongoing = true
+			case "false":
+				v.Ongoing = false
+			default:
+				return nil, errRestoreInvalid
+			}
+			ok = true
+		case "expiry-date":
+			t, err := time.Parse(http.TimeFormat, value)
+			if err != nil {
+				return nil, errRestoreInvalid
+			}
+			v.ExpiryDate = t
+		}
+	}
+	if !ok {
+		return nil, errRestoreInvalid
+	}
+	return v, nil
+}
+
+// Restore the archive object, the object is readable after the restore is done.
+//
+// The first optional Params is for Header, the second is for Query.
+//
+// Returns RestoreAlreadyInProgress Error if the restore is in progress.
+//
+// Relevant documentation:
+//
+// https://help.aliyun.com/document_detail/52930.html
+func (o Object) Restore(args ...Params) error {
+	header, query := getHeaderQuery(args)
+	query.Set("restore", "")
+	o.setVersionId(query)
+	return o.Do("POST", nil, nil, header, query)
+}
+
+// GetRestoreStatus returns the archive object restore status by the method Head,
+// returns nil if the restore has not been requested.
+//
+// The first optional Params is for Header, the second is for Query.
+func (o Object) GetRestoreStatus(args ...Params) (*RestoreStatus, error) {
+	h, err := o.Head(args...)
+	if err != nil {
+		return nil, err
+	}
+	if s := h.Get(HeaderRestore); s != "" {
+		return ParseRestore(s)
+	}
+	return nil, nil
+}
+
+// WaitRestore polls the object by the method Head every interval until it is readable,
+// returns the context error if the ctx is done first.
+//
+// The object which is not the archive storage class is always readable,
+// returns an error if the archive object has no restore status, call the method Restore first.
+//
+// The first optional Params is for Header, the second is for Query.
+func (o Object) WaitRestore(ctx context.Context, interval time.Duration, args ...Params) error {
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		h, err := o.Head(args...)
+		if err != nil {
+			return err
+		}
+		if !strings.EqualFold(h.Get("x-oss-storage-class"), StorageArchive) {
+			return nil
+		}
+		s := h.Get(HeaderRestore)
+		if s == "" {
+			return errRestoreNotRequested
+		}
+		v, err := ParseRestore(s)
+		if err != nil {
+			return err
+		}
+		if !v.Ongoing {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-t.C:
+		}
+	}
+}
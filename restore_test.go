@@ -0,0 +1,65 @@
+// Copyright 2015 Chen Xianren. All rights reserved.
+
+package oss
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestParseRestore(t *testing.T) {
+	v, err := ParseRestore(`ongoing-request="true"`)
+	fatal(t, err)
+	equal(t, "Ongoing", true, v.Ongoing)
+	equal(t, "ExpiryDate", true, v.ExpiryDate.IsZero())
+
+	v, err = ParseRestore(`ongoing-request="false", expiry-date="Sun, 16 Apr 2017 08:12:33 GMT"`)
+	fatal(t, err)
+	equal(t, "Ongoing", false, v.Ongoing)
+	equal(t, "ExpiryDate", int64(1492330353), v.ExpiryDate.Unix())
+
+	for _, s := range []string{"", `ongoing-request="maybe"`, `expiry-date="Sun, 16 Apr 2017 08:12:33 GMT"`, `ongoing-request="true`} {
+		if _, err = ParseRestore(s); err != errRestoreInvalid {
+			t.Fatal("expected", errRestoreInvalid, "but got", err, "for", s)
+		}
+	}
+}
+
+func TestObjectRestore(t *testing.T) {
+	o := newObject()
+
+	o.Bucket.StorageClass = StorageArchive
+	fatal(t, o.Bucket.Put())
+
+	_, err := o.Put([]byte(HelloWorld))
+	fatal(t, err)
+
+	var v []byte
+	err = o.Get(&v)
+	e, ok := err.(Error)
+	if !(ok && e.Code == "InvalidObjectState") {
+		t.Fatal("expected InvalidObjectState")
+	}
+
+	rs, err := o.GetRestoreStatus()
+	fatal(t, err)
+	if rs != nil {
+		t.Fatal("expected no restore status")
+	}
+	equal(t, "WaitRestore not requested", errRestoreNotRequested, o.WaitRestore(context.Background(), time.Millisecond))
+
+	fatal(t, o.Restore())
+
+	rs, err = o.GetRestoreStatus()
+	fatal(t, err)
+	equal(t, "Ongoing", true, rs != nil && rs.Ongoing)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	equal(t, "WaitRestore", context.DeadlineExceeded, o.WaitRestore(ctx, 100*time.Millisecond))
+
+	fatal(t, o.Delete())
+
+	fatal(t, o.Bucket.Delete())
+}
@@ -0,0 +1,310 @@
+// Copyright 2015 Chen Xianren. All rights reserved.
+
+package oss
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/xml"
+	"errors"
+	"hash/crc32"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Select CSV file header info
+const (
+	SelectFileHeaderNone   = "None"
+	SelectFileHeaderIgnore = "Ignore"
+	SelectFileHeaderUse    = "Use"
+)
+
+// Select JSON types
+const (
+	SelectJSONDocument = "DOCUMENT"
+	SelectJSONLines    = "LINES"
+)
+
+// Select frame types
+const (
+	SelectFrameData       = 8388609
+	SelectFrameContinuous = 8388612
+	SelectFrameEnd        = 8388613
+)
+
+// selectFrameMaxPayload limits the payload length of a frame,
+// the OSS frames are much smaller.
+const selectFrameMaxPayload = 64 << 20
+
+var (
+	errSelectInputRequired  = errors.New("select input serialization csv or json required")
+	errSelectFrameInvalid   = errors.New("select frame invalid")
+	errSelectFrameCorrupted = errors.New("select frame checksum mismatch")
+)
+
+// SelectRequest represents the select object request,
+// the Expression is the SQL such as "select * from ossobject where _1 > 100".
+//
+// The delimiters and the quote and the comment characters are plain strings,
+// they are base64 encoded when sending.
+//
+// Relevant documentation:
+//
+// https://help.aliyun.com/document_detail/74054.html
+type SelectRequest struct {
+	XMLName             xml.Name `xml:"SelectRequest"`
+	Expression          string
+	InputSerialization  SelectInputSerialization
+	OutputSerialization SelectOutputSerialization
+	Options             *SelectOptions `xml:",omitempty"`
+}
+
+// SelectInputSerialization represents the select input format, one of the CSV and the JSON is required,
+// the CompressionType is None or GZIP.
+type SelectInputSerialization struct {
+	CompressionType string           `xml:",omitempty"`
+	CSV             *SelectCSVInput  `xml:",omitempty"`
+	JSON            *SelectJSONInput `xml:",omitempty"`
+}
+
+// SelectCSVInput represents the CSV input format,
+// the Range is such as "line-range=10-20" or "split-range=10-20".
+type SelectCSVInput struct {
+	FileHeaderInfo             string `xml:",omitempty"`
+	RecordDelimiter            string `xml:",omitempty"`
+	FieldDelimiter             string `xml:",omitempty"`
+	QuoteCharacter             string `xml:",omitempty"`
+	CommentCharacter           string `xml:",omitempty"`
+	Range                      string `xml:",omitempty"`
+	AllowQuotedRecordDelimiter bool   `xml:",omitempty"`
+}
+
+// SelectJSONInput represents the JSON input format, the Type is DOCUMENT or LINES.
+type SelectJSONInput struct {
+	Type                    string
+	Range                   string `xml:",omitempty"`
+	ParseJsonNumberAsString bool   `xml:",omitempty"`
+}
+
+// SelectOutputSerialization represents the select output format.
+//
+// The payload CRC is always enabled and checked by the SelectObjectReader.
+type SelectOutputSerialization struct {
+	CSV              *SelectCSVOutput  `xml:",omitempty"`
+	JSON             *SelectJSONOutput `xml:",omitempty"`
+	KeepAllColumns   bool              `xml:",omitempty"`
+	OutputHeader     bool              `xml:",omitempty"`
+	EnablePayloadCrc bool
+}
+
+// SelectCSVOutput represents the CSV output format.
+type SelectCSVOutput struct {
+	RecordDelimiter string `xml:",omitempty"`
+	FieldDelimiter  string `xml:",omitempty"`
+}
+
+// SelectJSONOutput represents the JSON output format.
+type SelectJSONOutput struct {
+	RecordDelimiter string `xml:",omitempty"`
+}
+
+// SelectOptions represents the select options.
+type SelectOptions struct {
+	SkipPartialDataRecord    bool  `xml:",omitempty"`
+	MaxSkippedRecordsAllowed int64 `xml:",omitempty"`
+}
+
+// encode returns a copy of the request with the base64 encoded fields.
+func (r SelectRequest) encode() SelectRequest {
+	r.Expression = encodeSelect(r.Expression)
+	if i := r.InputSerialization.CSV; i != nil {
+		v := *i
+		v.RecordDelimiter = encodeSelect(v.RecordDelimiter)
+		v.FieldDelimiter = encodeSelect(v.FieldDelimiter)
+		v.QuoteCharacter = encodeSelect(v.QuoteCharacter)
+		v.CommentCharacter = encodeSelect(v.CommentCharacter)
+		r.InputSerialization.CSV = &v
+	}
+	if i := r.OutputSerialization.CSV; i != nil {
+		v := *i
+		v.RecordDelimiter = encodeSelect(v.RecordDelimiter)
+		v.FieldDelimiter = encodeSelect(v.FieldDelimiter)
+		r.OutputSerialization.CSV = &v
+	}
+	if i := r.OutputSerialization.JSON; i != nil {
+		v := *i
+		v.RecordDelimiter = encodeSelect(v.RecordDelimiter)
+		r.OutputSerialization.JSON = &v
+	}
+	r.OutputSerialization.EnablePayloadCrc = true
+	return r
+}
+
+func encodeSelect(s string) string {
+	if s == "" {
+		return ""
+	}
+	return base64.StdEncoding.EncodeToString([]byte(s))
+}
+
+// SelectObject queries the CSV or JSON object content by the SQL,
+// returns the reader of the results which must be closed.
+//
+// The first optional Params is for Header, the second is for Query.
+//
+// Relevant documentation:
+//
+// https://help.aliyun.com/document_detail/74054.html
+func (o Object) SelectObject(req SelectRequest, args ...Params) (*SelectObjectReader, error) {
+	header, query := getHeaderQuery(args)
+	switch {
+	case req.InputSerialization.CSV != nil:
+		query.Set(QueryProcess, "csv/select")
+	case req.InputSerialization.JSON != nil:
+		query.Set(QueryProcess, "json/select")
+	default:
+		return nil, errSelectInputRequired
+	}
+	o.setVersionId(query)
+
+	res, err := o.GetResponse("POST", req.encode(), header, query)
+	if err != nil {
+		return nil, err
+	}
+
+	err = newBody(res)
+	if err == nil {
+		err = readError(res)
+	}
+	if err != nil {
+		res.Body.Close()
+		return nil, err
+	}
+
+	return newSelectObjectReader(res), nil
+}
+
+// SelectObjectReader reads the select results from the framed response body,
+// returns io.EOF after the end frame, the Error if the end frame status is not succeed,
+// and io.ErrUnexpectedEOF if the body ends before the end frame.
+//
+// Each frame is:
+//
+//	version (1 byte) | type (3 bytes) | payload length (4 bytes) | header checksum (4 bytes) | payload | payload checksum (4 bytes)
+//
+// The data frame payload is the offset (8 bytes) and the data, the checksum is the CRC32 of the data.
+// The continuous frame and the end frame payload start with the offset and the total scanned bytes (8 bytes each),
+// the end frame also has the status (4 bytes) and the error message.
+type SelectObjectReader struct {
+	body   io.ReadCloser
+	header http.Header
+	data   []byte
+	err    error
+
+	offset, scanned int64
+}
+
+func newSelectObjectReader(res *http.Response) *SelectObjectReader {
+	return &SelectObjectReader{body: res.Body, header: res.Header}
+}
+
+// Read implements the io.Reader.
+func (r *SelectObjectReader) Read(p []byte) (int, error) {
+	for len(r.data) == 0 {
+		if r.err != nil {
+			return 0, r.err
+		}
+		r.err = r.next()
+	}
+	n := copy(p, r.data)
+	r.data = r.data[n:]
+	return n, nil
+}
+
+// Close closes the response body.
+func (r *SelectObjectReader) Close() error {
+	return r.body.Close()
+}
+
+// Offset returns the scanned offset of the object by the last frame.
+func (r *SelectObjectReader) Offset() int64 {
+	return r.offset
+}
+
+// ScannedBytes returns the total scanned bytes of the object by the last frame.
+func (r *SelectObjectReader) ScannedBytes() int64 {
+	return r.scanned
+}
+
+// next reads the next frame, sets the data if it is the data frame.
+func (r *SelectObjectReader) next() error {
+	var h [12]byte
+	if _, err := io.ReadFull(r.body, h[:]); err != nil {
+		return unexpectedEOF(err)
+	}
+	if h[0] != 1 {
+		return errSelectFrameInvalid
+	}
+	if sum := binary.BigEndian.Uint32(h[8:]); sum != 0 && crc32.ChecksumIEEE(h[:8]) != sum {
+		return errSelectFrameCorrupted
+	}
+	typ := binary.BigEndian.Uint32(h[:4]) & 0xffffff
+	n := int64(binary.BigEndian.Uint32(h[4:8]))
+	if n > selectFrameMaxPayload {
+		return errSelectFrameInvalid
+	}
+	payload := make([]byte, n+4)
+	if _, err := io.ReadFull(r.body, payload); err != nil {
+		return unexpectedEOF(err)
+	}
+	payload, sum := payload[:len(payload)-4], binary.BigEndian.Uint32(payload[len(payload)-4:])
+	if len(payload) < 8 {
+		return errSelectFrameInvalid
+	}
+	r.offset = int64(binary.BigEndian.Uint64(payload))
+
+	switch typ {
+	case SelectFrameData:
+		data := payload[8:]
+		if sum != 0 && crc32.ChecksumIEEE(data) != sum {
+			return errSelectFrameCorrupted
+		}
+		r.data = data
+	case SelectFrameContinuous:
+		if len(payload) >= 16 {
+			r.scanned = int64(binary.BigEndian.Uint64(payload[8:]))
+		}
+	case SelectFrameEnd:
+		if sum != 0 && crc32.ChecksumIEEE(payload) != sum {
+			return errSelectFrameCorrupted
+		}
+		if len(payload) < 20 {
+			return errSelectFrameInvalid
+		}
+		r.scanned = int64(binary.BigEndian.Uint64(payload[8:]))
+		if status := binary.BigEndian.Uint32(payload[16:]); status/100 != 2 {
+			e := Error{
+				Code:      strconv.Itoa(int(status)),
+				RequestId: r.header.Get("x-oss-request-id"),
+				Message:   string(payload[20:]),
+			}
+			if n := strings.Index(e.Message, "."); n > 0 {
+				e.Code = e.Message[:n]
+			}
+			return e
+		}
+		return io.EOF
+	default:
+		// ignore the unknown frames
+	}
+	return nil
+}
+
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
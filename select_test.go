@@ -0,0 +1,138 @@
+// Copyright 2015 Chen Xianren. All rights reserved.
+
+package oss
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/xml"
+	"hash/crc32"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"testing"
+)
+
+func selectFrame(typ uint32, payload []byte, sum uint32) []byte {
+	b := make([]byte, 12, 16+len(payload))
+	binary.BigEndian.PutUint32(b, 1<<24|typ)
+	binary.BigEndian.PutUint32(b[4:], uint32(len(payload)))
+	binary.BigEndian.PutUint32(b[8:], crc32.ChecksumIEEE(b[:8]))
+	b = append(b, payload...)
+	return append(b, byte(sum>>24), byte(sum>>16), byte(sum>>8), byte(sum))
+}
+
+func selectDataFrame(offset uint64, data string) []byte {
+	p := make([]byte, 8, 8+len(data))
+	binary.BigEndian.PutUint64(p, offset)
+	p = append(p, data...)
+	return selectFrame(SelectFrameData, p, crc32.ChecksumIEEE([]byte(data)))
+}
+
+func selectEndFrame(offset, scanned uint64, status uint32, msg string) []byte {
+	p := make([]byte, 20, 20+len(msg))
+	binary.BigEndian.PutUint64(p, offset)
+	binary.BigEndian.PutUint64(p[8:], scanned)
+	binary.BigEndian.PutUint32(p[16:], status)
+	p = append(p, msg...)
+	return selectFrame(SelectFrameEnd, p, crc32.ChecksumIEEE(p))
+}
+
+func newTestSelectReader(frames ...[]byte) *SelectObjectReader {
+	return newSelectObjectReader(&http.Response{
+		Header: http.Header{},
+		Body:   ioutil.NopCloser(bytes.NewReader(bytes.Join(frames, nil))),
+	})
+}
+
+func TestSelectRequestEncode(t *testing.T) {
+	r := SelectRequest{
+		Expression: "select * from ossobject",
+		InputSerialization: SelectInputSerialization{
+			CSV: &SelectCSVInput{FileHeaderInfo: SelectFileHeaderUse, FieldDelimiter: ","},
+		},
+		OutputSerialization: SelectOutputSerialization{
+			CSV: &SelectCSVOutput{RecordDelimiter: "\n"},
+		},
+	}
+	b, err := xml.Marshal(r.encode())
+	fatal(t, err)
+	equal(t, "SelectRequest",
+		"<SelectRequest><Expression>c2VsZWN0ICogZnJvbSBvc3NvYmplY3Q=</Expression>"+
+			"<InputSerialization><CSV><FileHeaderInfo>Use</FileHeaderInfo><FieldDelimiter>LA==</FieldDelimiter></CSV></InputSerialization>"+
+			"<OutputSerialization><CSV><RecordDelimiter>Cg==</RecordDelimiter></CSV><EnablePayloadCrc>true</EnablePayloadCrc></OutputSerialization>"+
+			"</SelectRequest>",
+		string(b))
+	equal(t, "unchanged FieldDelimiter", ",", r.InputSerialization.CSV.FieldDelimiter)
+}
+
+func TestSelectObjectReader(t *testing.T) {
+	continuous := make([]byte, 16)
+	binary.BigEndian.PutUint64(continuous, 10)
+	binary.BigEndian.PutUint64(continuous[8:], 10)
+
+	r := newTestSelectReader(
+		selectDataFrame(5, "a,1\n"),
+		selectFrame(SelectFrameContinuous, continuous, 0),
+		selectDataFrame(12, "b,2\n"),
+		selectEndFrame(20, 20, 200, ""),
+	)
+	b, err := ioutil.ReadAll(r)
+	fatal(t, err)
+	equal(t, "data", "a,1\nb,2\n", string(b))
+	equal(t, "Offset", int64(20), r.Offset())
+	equal(t, "ScannedBytes", int64(20), r.ScannedBytes())
+	fatal(t, r.Close())
+
+	r = newTestSelectReader(selectDataFrame(5, "a,1\n"))
+	_, err = ioutil.ReadAll(r)
+	equal(t, "no end frame", io.ErrUnexpectedEOF, err)
+
+	f := selectDataFrame(5, "a,1\n")
+	f[len(f)-1]++
+	_, err = ioutil.ReadAll(newTestSelectReader(f))
+	equal(t, "corrupted", errSelectFrameCorrupted, err)
+
+	f = selectDataFrame(5, "a,1\n")
+	f[11]++
+	_, err = ioutil.ReadAll(newTestSelectReader(f))
+	equal(t, "header corrupted", errSelectFrameCorrupted, err)
+
+	f = selectDataFrame(5, "a,1\n")
+	binary.BigEndian.PutUint32(f[4:], 0xfffffffc)
+	binary.BigEndian.PutUint32(f[8:], 0)
+	_, err = ioutil.ReadAll(newTestSelectReader(f))
+	equal(t, "payload too large", errSelectFrameInvalid, err)
+
+	_, err = ioutil.ReadAll(newTestSelectReader(selectEndFrame(0, 0, 400, "InvalidCsvLine.line 3 invalid")))
+	equal(t, "end frame error", "InvalidCsvLine", ErrorCode(err))
+}
+
+func TestObjectSelectObject(t *testing.T) {
+	o := newObject()
+	o.Name = "aliyun-oss-go-sdk.csv"
+
+	fatal(t, o.Bucket.Put())
+
+	_, err := o.Put([]byte("name,age\nalice,20\nbob,30\ncarol,40\n"))
+	fatal(t, err)
+
+	r, err := o.SelectObject(SelectRequest{
+		Expression: "select name from ossobject where cast(age as int) > 25",
+		InputSerialization: SelectInputSerialization{
+			CSV: &SelectCSVInput{FileHeaderInfo: SelectFileHeaderUse},
+		},
+		OutputSerialization: SelectOutputSerialization{
+			CSV: &SelectCSVOutput{},
+		},
+	})
+	fatal(t, err)
+	b, err := ioutil.ReadAll(r)
+	fatal(t, err)
+	fatal(t, r.Close())
+	equal(t, "select", "bob\ncarol\n", string(b))
+
+	fatal(t, o.Delete())
+
+	fatal(t, o.Bucket.Delete())
+}
@@ -4,6 +4,7 @@ package oss
 
 import (
 	"bytes"
+	"context"
 	"encoding/xml"
 	"errors"
 	"io/ioutil"
@@ -26,6 +27,35 @@ var (
 	errPartNumberInvalid    = errors.New("part number invalid")
 	errSourceObjectInvalid  = errors.New("source object invalid")
 	errRangeInvalid         = errors.New("range invalid")
+	errExpiresInvalid       = errors.New("expires invalid")
+	errNextMarkerMissing    = errors.New("next marker missing of the truncated list")
+
+	errLifecycleRulesInvalid        = errors.New("lifecycle rules must be 1 to 1000")
+	errLifecycleIdInvalid           = errors.New("lifecycle rule id too long or duplicate")
+	errLifecycleStatusInvalid       = errors.New("lifecycle rule status invalid")
+	errLifecycleActionRequired      = errors.New("lifecycle rule action required")
+	errLifecycleExpirationInvalid   = errors.New("lifecycle expiration must have exactly one of Days, Date, CreatedBeforeDate and ExpiredObjectDeleteMarker")
+	errLifecycleDaysDateInvalid     = errors.New("lifecycle action must have exactly one of days and date")
+	errLifecycleDaysInvalid         = errors.New("lifecycle days must be positive")
+	errLifecycleDateInvalid         = errors.New("lifecycle date must be ISO8601 midnight")
+	errLifecycleStorageClassInvalid = errors.New("lifecycle storage class must be IA or Archive")
+	errLifecycleTransitionInvalid   = errors.New("lifecycle transition must be earlier than expiration")
+	errLifecycleTagsNotAllowed      = errors.New("lifecycle ExpiredObjectDeleteMarker and AbortMultipartUpload can not be used with tags")
+	errLifecycleTagKeyRequired      = errors.New("lifecycle tag key required")
+
+	errWebsiteIndexTypeInvalid    = errors.New("website index document type invalid")
+	errWebsiteRulesTooMany        = errors.New("website routing rules must be at most 20")
+	errWebsiteRuleNumberInvalid   = errors.New("website routing rule number invalid")
+	errWebsiteConditionRequired   = errors.New("website condition KeyPrefixEquals or HttpErrorCodeReturnedEquals required")
+	errWebsiteHeaderKeyRequired   = errors.New("website condition include header key required")
+	errWebsiteMirrorCodeInvalid   = errors.New("website mirror condition HttpErrorCodeReturnedEquals must be 404")
+	errWebsiteMirrorURLInvalid    = errors.New("website mirror url must be http or https and end with the slash")
+	errWebsiteHostNameRequired    = errors.New("website redirect host name required")
+	errWebsiteInternalInvalid     = errors.New("website internal redirect can not have mirror url or host name")
+	errWebsiteRedirectTypeInvalid = errors.New("website redirect type invalid")
+	errWebsiteRedirectCodeInvalid = errors.New("website redirect code invalid")
+	errWebsiteProtocolInvalid     = errors.New("website redirect protocol invalid")
+	errWebsiteReplaceKeyExclusive = errors.New("website redirect ReplaceKeyWith and ReplaceKeyPrefixWith are exclusive")
 )
 
 // Service represents Aliyun Object Storage Service,
@@ -36,6 +66,8 @@ type Service struct {
 	AccessKeyId     string
 	AccessKeySecret string
 	SecurityToken   string // STS
+
+	ctx context.Context
 }
 
 // NewService returns a new Service given a accessKeyId and accessKeySecret.
@@ -54,6 +86,17 @@ func (s Service) NewBucket(bucketName string) Bucket {
 	}
 }
 
+// WithContext returns a copy of the service which sends the requests with the ctx,
+// the requests are canceled when the ctx is done.
+//
+// The Bucket and the Object use it by replacing the embedded Service, for example:
+//
+//	o.Service = o.Service.WithContext(ctx)
+func (s Service) WithContext(ctx context.Context) Service {
+	s.ctx = ctx
+	return s
+}
+
 // Scheme returns http if the Unsafe is ture otherwise returns https.
 func (s Service) Scheme() string {
 	if s.Unsafe {
@@ -100,6 +143,9 @@ func (s Service) GetResponse(method, bucket, object string, body interface{}, ar
 		return nil, err
 	}
 	s.Signature(req, 0)
+	if s.ctx != nil {
+		req = req.WithContext(s.ctx)
+	}
 	return http.DefaultClient.Do(req)
 }
 
@@ -282,7 +328,9 @@ func (s Service) Signature(req *http.Request, seconds int) {
 //
 // The first optional Params is for Header, the second is for Query.
 //
-// Query predefine parameters: prefix, marker, max-keys.
+// Query predefine parameters: prefix, marker, max-keys, tag-key, tag-value, tagging.
+//
+// Header predefine parameters: x-oss-resource-group-id.
 //
 // Relevant documentation:
 //
@@ -298,6 +346,23 @@ func (s Service) ListBucket(args ...Params) (*ListAllMyBucketsResult, error) {
 	return v, nil
 }
 
+// ListBucketByTag returns the buckets which have the tag given the key and the value,
+// all the buckets which have the tag key if the value is the empty string.
+//
+// The first optional Params is for Header, the second is for Query.
+//
+// Relevant documentation:
+//
+// https://help.aliyun.com/document_detail/31957.html
+func (s Service) ListBucketByTag(key, value string, args ...Params) (*ListAllMyBucketsResult, error) {
+	header, query := getHeaderQuery(args)
+	query.Set("tag-key", key)
+	if value != "" {
+		query.Set("tag-value", value)
+	}
+	return s.ListBucket(header, query)
+}
+
 // ListAllMyBucketsResult represents the get service result.
 //
 // Relevant documentation:
@@ -312,9 +377,11 @@ type ListAllMyBucketsResult struct {
 	Owner       Owner
 	Buckets     struct {
 		Bucket []struct {
-			Location     string
-			Name         string
-			CreationDate string
+			Location        string
+			Name            string
+			CreationDate    string
+			StorageClass    string
+			ResourceGroupId string
 		}
 	}
 }
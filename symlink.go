@@ -0,0 +1,67 @@
+// Copyright 2015 Chen Xianren. All rights reserved.
+
+package oss
+
+import (
+	"net/url"
+	"strings"
+)
+
+// HeaderSymlinkTarget is the header of the symlink target object name.
+const HeaderSymlinkTarget = "x-oss-symlink-target"
+
+// PutSymlink create the object as a symlink to the target object in the same bucket,
+// also send the ACL and the storage class if they are not the empty string.
+//
+// The first optional Params is for Header, the second is for Query.
+//
+// The user metadata x-oss-meta-* can be set by the header.
+//
+// Relevant documentation:
+//
+// https://help.aliyun.com/document_detail/45126.html
+func (o Object) PutSymlink(target string, args ...Params) error {
+	if !IsObjectName(target) {
+		return errObjectNameInvalid
+	}
+
+	header, query := getHeaderQuery(args)
+	query.Set("symlink", "")
+	// escape the spaces as %20 since the OSS does not decode the + of the header
+	header.Set(HeaderSymlinkTarget, strings.Replace(url.QueryEscape(target), "+", "%20", -1))
+
+	if o.ACL != "" {
+		header.Set("x-oss-object-acl", o.ACL)
+	}
+	if o.StorageClass != "" {
+		header.Set("x-oss-storage-class", o.StorageClass)
+	}
+
+	return o.Do("PUT", nil, nil, header, query)
+}
+
+// GetSymlink returns the target object name of the symlink,
+// returns NotSymlink Error if the object is not a symlink.
+//
+// The first optional Params is for Header, the second is for Query.
+//
+// Relevant documentation:
+//
+// https://help.aliyun.com/document_detail/45146.html
+func (o Object) GetSymlink(args ...Params) (string, error) {
+	header, query := getHeaderQuery(args)
+	query.Set("symlink", "")
+	o.setVersionId(query)
+
+	res, err := o.GetResponse("GET", nil, header, query)
+	if err != nil {
+		return "", err
+	}
+
+	err = ReadBody(res, nil)
+	if err != nil {
+		return "", err
+	}
+
+	return url.QueryUnescape(res.Header.Get(HeaderSymlinkTarget))
+}
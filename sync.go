@@ -0,0 +1,324 @@
+// Copyright 2015 Chen Xianren. All rights reserved.
+
+package oss
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"mime"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Sync metadata headers, set by the Sync uploads to compare the multipart objects
+// which ETag is not the MD5 of the content.
+const (
+	HeaderSyncMD5   = "x-oss-meta-md5"
+	HeaderSyncMtime = "x-oss-meta-mtime"
+)
+
+// SyncOptions represents the Sync options.
+//
+// The Include and the Exclude are the path.Match patterns of the slash separated relative paths,
+// a pattern without "/" matches the base name. If the Include is not empty the path must match one of them,
+// and the path must not match any of the Exclude. The excluded remote objects are not deleted.
+type SyncOptions struct {
+	Delete   bool      // delete the remote objects which are not in the local directory
+	DryRun   bool      // only write the actions to the Output
+	Include  []string  // the include patterns
+	Exclude  []string  // the exclude patterns
+	PartSize int64     // see the method UploadFile
+	Routines int       // the number of the parts uploaded in parallel, shared by the files
+	Output   io.Writer // write an action per line if not nil
+}
+
+// SyncResult represents the Sync result, the keys are sorted.
+type SyncResult struct {
+	Uploaded []string
+	Deleted  []string
+	Skipped  []string
+}
+
+type syncLocal struct {
+	path  string
+	size  int64
+	mtime int64
+	md5   string
+}
+
+func (l *syncLocal) sum() (string, error) {
+	if l.md5 != "" {
+		return l.md5, nil
+	}
+	f, err := os.Open(l.path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := md5.New()
+	if _, err = io.Copy(h, f); err != nil {
+		return "", err
+	}
+	l.md5 = hex.EncodeToString(h.Sum(nil))
+	return l.md5, nil
+}
+
+type syncRemote struct {
+	size int64
+	etag string
+}
+
+func (opt SyncOptions) match(rel string) bool {
+	m := func(patterns []string) bool {
+		for _, p := range patterns {
+			s := rel
+			if !strings.Contains(p, "/") {
+				s = path.Base(rel)
+			}
+			if ok, _ := path.Match(p, s); ok {
+				return true
+			}
+		}
+		return false
+	}
+	if len(opt.Include) > 0 && !m(opt.Include) {
+		return false
+	}
+	return !m(opt.Exclude)
+}
+
+func (opt SyncOptions) printf(format string, a ...interface{}) {
+	if opt.Output == nil {
+		return
+	}
+	if opt.DryRun {
+		format = "(dryrun) " + format
+	}
+	fmt.Fprintf(opt.Output, format+"\n", a...)
+}
+
+// Sync the local directory to the prefix of the bucket,
+// uploads the new and changed files and deletes the extraneous objects if the Delete option is true.
+//
+// The first optional Params is for Header, the second is for Query,
+// both are sent with the uploads, the Content-Type is set by the file extension if it is not given.
+//
+// A file is changed if the size is different, or the content MD5 is different from the ETag,
+// or the ETag is not the MD5 and the Sync metadata is missing or different.
+//
+// The objects which key ends with "/" are treated as the directories and ignored.
+//
+// The Routines are shared, at most Routines files are uploaded in parallel
+// and the rest of the Routines are split to the parts of each file,
+// so at most Routines parts are buffered, that is Routines * PartSize of memory.
+func (b Bucket) Sync(dir, prefix string, opt SyncOptions, args ...Params) (*SyncResult, error) {
+	for _, p := range append(opt.Include, opt.Exclude...) {
+		if _, err := path.Match(p, ""); err != nil {
+			return nil, err
+		}
+	}
+	if prefix != "" && !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+	if opt.Routines <= 0 {
+		opt.Routines = 1
+	}
+
+	locals := map[string]*syncLocal{}
+	err := filepath.Walk(dir, func(p string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !fi.Mode().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if opt.match(rel) {
+			locals[rel] = &syncLocal{path: p, size: fi.Size(), mtime: fi.ModTime().Unix()}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	remotes, err := b.syncList(prefix, opt)
+	if err != nil {
+		return nil, err
+	}
+
+	v := new(SyncResult)
+
+	var uploads []string
+	for rel, l := range locals {
+		r, ok := remotes[rel]
+		if ok {
+			changed, err := b.syncChanged(prefix+rel, l, r)
+			if err != nil {
+				return nil, err
+			}
+			if !changed {
+				v.Skipped = append(v.Skipped, prefix+rel)
+				continue
+			}
+		}
+		uploads = append(uploads, rel)
+	}
+	sort.Strings(uploads)
+	sort.Strings(v.Skipped)
+
+	if opt.DryRun {
+		for _, rel := range uploads {
+			opt.printf("upload: %s to %s", locals[rel].path, prefix+rel)
+			v.Uploaded = append(v.Uploaded, prefix+rel)
+		}
+	} else {
+		files := opt.Routines
+		if files > len(uploads) {
+			files = len(uploads)
+		}
+		parts := 1
+		if files > 0 {
+			parts = opt.Routines / files
+		}
+
+		var mu sync.Mutex
+		err = parallel(len(uploads), files, func(i int) error {
+			l, key := locals[uploads[i]], prefix+uploads[i]
+
+			mu.Lock()
+			opt.printf("upload: %s to %s", l.path, key)
+			mu.Unlock()
+
+			if err := b.syncUpload(key, l, opt.PartSize, parts, args...); err != nil {
+				return err
+			}
+
+			mu.Lock()
+			v.Uploaded = append(v.Uploaded, key)
+			mu.Unlock()
+			return nil
+		})
+		sort.Strings(v.Uploaded)
+		if err != nil {
+			return v, err
+		}
+	}
+
+	if opt.Delete {
+		var keys []string
+		for rel := range remotes {
+			if _, ok := locals[rel]; !ok {
+				keys = append(keys, prefix+rel)
+			}
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			opt.printf("delete: %s", k)
+		}
+		if opt.DryRun {
+			v.Deleted = keys
+		} else if len(keys) > 0 {
+			r := b.BulkDelete(keys, opt.Routines)
+			v.Deleted = r.Deleted
+			if err = r.Err(); err != nil {
+				return v, err
+			}
+		}
+	}
+
+	return v, nil
+}
+
+// syncList returns the objects of the prefix by the relative paths.
+func (b Bucket) syncList(prefix string, opt SyncOptions) (map[string]syncRemote, error) {
+	remotes := map[string]syncRemote{}
+	it := b.NewObjectIterator(nil, Params{
+		"prefix":   {prefix},
+		"max-keys": {"1000"},
+	})
+	for it.Next() {
+		for _, i := range it.Result().Contents {
+			rel := strings.TrimPrefix(i.Key, prefix)
+			if rel == "" || strings.HasSuffix(rel, "/") || !opt.match(rel) {
+				continue
+			}
+			size, err := strconv.ParseInt(i.Size, 10, 64)
+			if err != nil {
+				return nil, err
+			}
+			remotes[rel] = syncRemote{size, strings.Trim(i.ETag, `"`)}
+		}
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	return remotes, nil
+}
+
+// syncChanged reports whether the local file is different from the object.
+func (b Bucket) syncChanged(key string, l *syncLocal, r syncRemote) (bool, error) {
+	if l.size != r.size {
+		return true, nil
+	}
+	if len(r.etag) == md5.Size*2 {
+		if _, err := hex.DecodeString(r.etag); err == nil {
+			sum, err := l.sum()
+			if err != nil {
+				return false, err
+			}
+			return !strings.EqualFold(sum, r.etag), nil
+		}
+	}
+	h, err := Object{Bucket: b, Name: key}.Head()
+	if err != nil {
+		return false, err
+	}
+	if s := h.Get(HeaderSyncMD5); s != "" {
+		sum, err := l.sum()
+		if err != nil {
+			return false, err
+		}
+		return !strings.EqualFold(sum, s), nil
+	}
+	return h.Get(HeaderSyncMtime) != strconv.FormatInt(l.mtime, 10), nil
+}
+
+// syncUpload upload the local file as the object with the Sync metadata.
+func (b Bucket) syncUpload(key string, l *syncLocal, partSize int64, routines int, args ...Params) error {
+	sum, err := l.sum()
+	if err != nil {
+		return err
+	}
+
+	header, query := Params{}, Params{}
+	header.Copy(getParams(args, 0))
+	query.Copy(getParams(args, 1))
+	header.Set(HeaderSyncMD5, sum)
+	header.Set(HeaderSyncMtime, strconv.FormatInt(l.mtime, 10))
+	if header.Get("Content-Type") == "" {
+		if t := mime.TypeByExtension(path.Ext(key)); t != "" {
+			header.Set("Content-Type", t)
+		}
+	}
+
+	f, err := os.Open(l.path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = Object{Bucket: b, Name: key}.UploadFile(f, partSize, routines, header, query)
+	return err
+}
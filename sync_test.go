@@ -0,0 +1,142 @@
+// Copyright 2015 Chen Xianren. All rights reserved.
+
+package oss
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSyncOptionsMatch(t *testing.T) {
+	opt := SyncOptions{
+		Include: []string{"*.html", "static/*"},
+		Exclude: []string{"*.tmp", "static/private.*"},
+	}
+	for rel, expected := range map[string]bool{
+		"index.html":        true,
+		"a/b/page.html":     true,
+		"static/app.js":     true,
+		"static/private.js": false,
+		"static/a/b.js":     false,
+		"readme.md":         false,
+		"static/x.tmp":      false,
+		"a/b/page.html.tmp": false,
+		"static/index.html": true,
+	} {
+		equal(t, rel, expected, opt.match(rel))
+	}
+	equal(t, "no patterns", true, SyncOptions{}.match("any/thing"))
+}
+
+func TestBucketSyncRoutines(t *testing.T) {
+	f, b := newFakeOSS(t)
+
+	var (
+		mu          sync.Mutex
+		parts, peak int
+	)
+	f.before = func(w http.ResponseWriter, r *http.Request) bool {
+		if r.Method != "PUT" || r.URL.Query().Get("partNumber") == "" {
+			return false
+		}
+		mu.Lock()
+		parts++
+		if parts > peak {
+			peak = parts
+		}
+		mu.Unlock()
+
+		time.Sleep(10 * time.Millisecond) // let the parallel parts overlap
+		f.mu.Lock()
+		f.multipart(w, r, strings.TrimPrefix(r.URL.Path, "/"))
+		f.mu.Unlock()
+
+		mu.Lock()
+		parts--
+		mu.Unlock()
+		return true
+	}
+
+	dir, err := ioutil.TempDir("", "aliyun-oss-go-sdk")
+	fatal(t, err)
+	defer os.RemoveAll(dir)
+
+	data := []byte(strings.Repeat("x", 3*MultipartUploadMinPartSize))
+	for _, name := range []string{"a", "b", "c", "d"} {
+		fatal(t, ioutil.WriteFile(filepath.Join(dir, name), data, 0644))
+	}
+
+	r, err := b.Sync(dir, "", SyncOptions{PartSize: MultipartUploadMinPartSize, Routines: 3})
+	fatal(t, err)
+	equal(t, "Uploaded", 4, len(r.Uploaded))
+	if peak > 3 {
+		t.Fatal("expected at most 3 parts in parallel but got", peak)
+	}
+}
+
+func TestBucketSync(t *testing.T) {
+	b := newBucket()
+
+	fatal(t, b.Put())
+
+	dir, err := ioutil.TempDir("", "aliyun-oss-go-sdk")
+	fatal(t, err)
+	defer os.RemoveAll(dir)
+
+	for name, content := range map[string]string{
+		"index.html":    HelloWorld,
+		"css/main.css":  "body{}",
+		"debug.tmp":     "tmp",
+		"js/big/app.js": strings.Repeat("x", MultipartUploadMinPartSize+1),
+	} {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		fatal(t, os.MkdirAll(filepath.Dir(p), 0755))
+		fatal(t, ioutil.WriteFile(p, []byte(content), 0644))
+	}
+
+	extra := Object{Bucket: b, Name: "site/extra.txt"}
+	_, err = extra.Put([]byte(HelloWorld))
+	fatal(t, err)
+
+	opt := SyncOptions{
+		Delete:   true,
+		Exclude:  []string{"*.tmp"},
+		PartSize: MultipartUploadMinPartSize,
+		Routines: 2,
+	}
+
+	var out bytes.Buffer
+	opt.DryRun, opt.Output = true, &out
+	r, err := b.Sync(dir, "site", opt)
+	fatal(t, err)
+	equal(t, "dry-run Uploaded", 3, len(r.Uploaded))
+	equal(t, "dry-run Deleted", 1, len(r.Deleted))
+	equal(t, "dry-run output lines", 4, strings.Count(out.String(), "(dryrun) "))
+
+	opt.DryRun, opt.Output = false, nil
+	r, err = b.Sync(dir, "site", opt)
+	fatal(t, err)
+	equal(t, "Uploaded", 3, len(r.Uploaded))
+	equal(t, "Deleted", "site/extra.txt", r.Deleted[0])
+
+	h, err := Object{Bucket: b, Name: "site/index.html"}.Head()
+	fatal(t, err)
+	equal(t, "Content-Type", "text/html; charset=utf-8", h.Get("Content-Type"))
+
+	r, err = b.Sync(dir, "site/", opt)
+	fatal(t, err)
+	equal(t, "Uploaded", 0, len(r.Uploaded))
+	equal(t, "Skipped", 3, len(r.Skipped))
+
+	_, err = b.DeletePrefix("site/", 1)
+	fatal(t, err)
+
+	fatal(t, b.Delete())
+}
@@ -0,0 +1,162 @@
+// Copyright 2015 Chen Xianren. All rights reserved.
+
+package oss
+
+import (
+	"net/url"
+	"strings"
+)
+
+// HeaderTagging is the header of the object tagging when upload or copy.
+const HeaderTagging = "x-oss-tagging"
+
+// PutTagging set the object tagging, replace it if already exists.
+//
+// The first optional Params is for Header, the second is for Query.
+//
+// Relevant documentation:
+//
+// https://help.aliyun.com/document_detail/114855.html
+func (o Object) PutTagging(tagging Tagging, args ...Params) error {
+	header, query := getHeaderQuery(args)
+	query.Set("tagging", "")
+	o.setVersionId(query)
+	return o.Do("PUT", tagging, nil, header, query)
+}
+
+// GetTagging returns the object tagging.
+//
+// The first optional Params is for Header, the second is for Query.
+//
+// Get and record:
+//
+//	o.Tagging, err = o.GetTagging()
+//
+// Relevant documentation:
+//
+// https://help.aliyun.com/document_detail/114878.html
+func (o Object) GetTagging(args ...Params) (*Tagging, error) {
+	header, query := getHeaderQuery(args)
+	query.Set("tagging", "")
+	o.setVersionId(query)
+
+	v := new(Tagging)
+
+	err := o.Do("GET", nil, v, header, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+// DeleteTagging remove all the tags of the object.
+//
+// The first optional Params is for Header, the second is for Query.
+//
+// Relevant documentation:
+//
+// https://help.aliyun.com/document_detail/114879.html
+func (o Object) DeleteTagging(args ...Params) error {
+	header, query := getHeaderQuery(args)
+	query.Set("tagging", "")
+	o.setVersionId(query)
+	return o.Do("DELETE", nil, nil, header, query)
+}
+
+// PutTagging set the bucket tagging, replace it if already exists.
+//
+// The first optional Params is for Header, the second is for Query.
+//
+// Relevant documentation:
+//
+// https://help.aliyun.com/document_detail/163258.html
+func (b Bucket) PutTagging(tagging Tagging, args ...Params) error {
+	header, query := getHeaderQuery(args)
+	query.Set("tagging", "")
+	return b.Do("PUT", "", tagging, nil, header, query)
+}
+
+// GetTagging returns the bucket tagging.
+//
+// The first optional Params is for Header, the second is for Query.
+//
+// Relevant documentation:
+//
+// https://help.aliyun.com/document_detail/163259.html
+func (b Bucket) GetTagging(args ...Params) (*Tagging, error) {
+	header, query := getHeaderQuery(args)
+	query.Set("tagging", "")
+
+	v := new(Tagging)
+
+	err := b.Do("GET", "", nil, v, header, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+// DeleteTagging remove the tags of the bucket given the keys, all the tags if the keys is empty.
+//
+// The first optional Params is for Header, the second is for Query.
+//
+// Relevant documentation:
+//
+// https://help.aliyun.com/document_detail/163260.html
+func (b Bucket) DeleteTagging(keys []string, args ...Params) error {
+	header, query := getHeaderQuery(args)
+	query.Set("tagging", strings.Join(keys, ","))
+	return b.Do("DELETE", "", nil, nil, header, query)
+}
+
+// Tag represents a tag of the tagging.
+type Tag struct {
+	Key   string
+	Value string
+}
+
+// Tagging represents the tagging.
+//
+// Relevant documentation:
+//
+// https://help.aliyun.com/document_detail/114855.html
+type Tagging struct {
+	TagSet struct {
+		Tag []Tag
+	}
+}
+
+// NewTagging returns a new Tagging given the key value pairs, the order is kept.
+func NewTagging(tags ...Tag) Tagging {
+	var v Tagging
+	v.TagSet.Tag = tags
+	return v
+}
+
+// Add adds the tag, it appends to any existing tags.
+func (v *Tagging) Add(key, value string) {
+	v.TagSet.Tag = append(v.TagSet.Tag, Tag{key, value})
+}
+
+// Get gets the value of the first tag associated with the given key.
+// If there are no tags associated with the key, Get returns the empty string.
+func (v Tagging) Get(key string) string {
+	for _, i := range v.TagSet.Tag {
+		if i.Key == key {
+			return i.Value
+		}
+	}
+	return ""
+}
+
+// Encode returns the URL encoded form of the tagging, such as "k1=v1&k2=v2",
+// is used as the x-oss-tagging header.
+func (v Tagging) Encode() string {
+	a := make([]string, len(v.TagSet.Tag))
+	for k, i := range v.TagSet.Tag {
+		a[k] = url.QueryEscape(i.Key) + "=" + url.QueryEscape(i.Value)
+	}
+	return strings.Join(a, "&")
+}
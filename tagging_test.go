@@ -0,0 +1,119 @@
+// Copyright 2015 Chen Xianren. All rights reserved.
+
+package oss
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestTaggingEncode(t *testing.T) {
+	v := NewTagging(Tag{"project", "oss sdk"})
+	v.Add("retention", "30&days")
+	v.Add("中文", "=")
+
+	equal(t, "Encode", "project=oss+sdk&retention=30%26days&%E4%B8%AD%E6%96%87=%3D", v.Encode())
+	equal(t, "Get", "30&days", v.Get("retention"))
+	equal(t, "Get", "", v.Get("missing"))
+
+	u, err := url.Parse("http://oss-example.oss-cn-hangzhou.aliyuncs.com/nelson?tagging")
+	fatal(t, err)
+	equal(t, "CanonicalizedResource", "/oss-example/nelson?tagging", CanonicalizedResource(u))
+
+	equal(t, "Object comparable", true, Object{Tagging: &v} == Object{Tagging: &v})
+}
+
+func TestObjectTagging(t *testing.T) {
+	o := newObject()
+
+	fatal(t, o.Bucket.Put())
+
+	tagging := NewTagging(Tag{"project", "oss sdk"})
+	o.Tagging = &tagging
+	_, err := o.Put([]byte(HelloWorld))
+	fatal(t, err)
+
+	v, err := o.GetTagging()
+	fatal(t, err)
+	equal(t, "Tag", 1, len(v.TagSet.Tag))
+	equal(t, "Tag.Value", "oss sdk", v.Get("project"))
+
+	v.Add("retention", "30&days")
+	fatal(t, o.PutTagging(*v))
+
+	v, err = o.GetTagging()
+	fatal(t, err)
+	equal(t, "Tag", 2, len(v.TagSet.Tag))
+	equal(t, "Tag.Value", "30&days", v.Get("retention"))
+
+	tagging = NewTagging(Tag{"copy", "true"})
+	dst := Object{
+		Bucket:  o.Bucket,
+		Name:    "copy-" + o.Name,
+		Tagging: &tagging,
+	}
+	_, err = dst.Copy(o)
+	fatal(t, err)
+
+	v, err = dst.GetTagging()
+	fatal(t, err)
+	equal(t, "Tag", 1, len(v.TagSet.Tag))
+	equal(t, "Tag.Value", "true", v.Get("copy"))
+
+	fatal(t, o.DeleteTagging())
+
+	v, err = o.GetTagging()
+	fatal(t, err)
+	equal(t, "Tag", 0, len(v.TagSet.Tag))
+
+	fatal(t, dst.Delete())
+	fatal(t, o.Delete())
+
+	fatal(t, o.Bucket.Delete())
+}
+
+func TestBucketTagging(t *testing.T) {
+	b := newBucket()
+
+	fatal(t, b.Put())
+
+	fatal(t, b.PutTagging(NewTagging(Tag{"project", "oss sdk"}, Tag{"owner", "cxr29"})))
+
+	v, err := b.GetTagging()
+	fatal(t, err)
+	equal(t, "Tag", 2, len(v.TagSet.Tag))
+	equal(t, "Tag.Value", "oss sdk", v.Get("project"))
+
+	r, err := b.Service.ListBucketByTag("project", "oss sdk")
+	fatal(t, err)
+	found := false
+	for _, i := range r.Buckets.Bucket {
+		if i.Name == b.Name {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatal("ListBucketByTag not found the bucket")
+	}
+
+	fatal(t, b.DeleteTagging([]string{"owner"}))
+
+	v, err = b.GetTagging()
+	fatal(t, err)
+	equal(t, "Tag", 1, len(v.TagSet.Tag))
+
+	fatal(t, b.DeleteTagging(nil))
+
+	v, err = b.GetTagging()
+	fatal(t, err)
+	equal(t, "Tag", 0, len(v.TagSet.Tag))
+
+	id, err := b.GetResourceGroup()
+	fatal(t, err)
+	if id == "" {
+		t.Fatal("expected ResourceGroupId")
+	}
+	fatal(t, b.PutResourceGroup(id))
+
+	fatal(t, b.Delete())
+}
@@ -0,0 +1,81 @@
+// Copyright 2015 Chen Xianren. All rights reserved.
+
+package oss
+
+import (
+	"io"
+	"os"
+)
+
+// Multipart Upload defaults and limits
+const (
+	MultipartUploadPartSize    = 10 << 20 // 10M
+	MultipartUploadMinPartSize = 100 << 10
+	MultipartUploadMaxParts    = 10000
+)
+
+// UploadFile upload the file as the object content, returns the ETag.
+//
+// The file is sent by the method Put if the size is not greater than the partSize,
+// otherwise it is split into parts of the partSize and at most routines parts are uploaded in parallel
+// by the Multipart Upload, the Multipart Upload is aborted if any part failed.
+//
+// The first optional Params is for Header, the second is for Query,
+// both are sent with the Put or the InitiateMultipartUpload.
+//
+// The partSize will be increased if the parts are more than 10000,
+// the MultipartUploadPartSize is used if the partSize <= 0.
+//
+// It reads the file from the beginning and does not close it.
+func (o Object) UploadFile(file *os.File, partSize int64, routines int, args ...Params) (string, error) {
+	fi, err := file.Stat()
+	if err != nil {
+		return "", err
+	}
+	total := fi.Size()
+
+	if partSize <= 0 {
+		partSize = MultipartUploadPartSize
+	} else if partSize < MultipartUploadMinPartSize {
+		partSize = MultipartUploadMinPartSize
+	}
+	if n := (total + MultipartUploadMaxParts - 1) / MultipartUploadMaxParts; partSize < n {
+		partSize = n
+	}
+	if routines <= 0 {
+		routines = 1
+	}
+
+	if total <= partSize {
+		if _, err = file.Seek(0, io.SeekStart); err != nil {
+			return "", err
+		}
+		return o.Put(file, args...)
+	}
+
+	header, query := getHeaderQuery(args)
+	if o.ACL != "" {
+		header.Set("x-oss-object-acl", o.ACL)
+	}
+
+	n := int((total + partSize - 1) / partSize)
+
+	v, err := o.multipartUpload(n, routines, func(partNumber int, uploadId string) (string, error) {
+		first := int64(partNumber-1) * partSize
+		length := partSize
+		if first+length > total {
+			length = total - first
+		}
+
+		data := make([]byte, length)
+		if _, err := file.ReadAt(data, first); err != nil {
+			return "", err
+		}
+		return o.UploadPart(partNumber, uploadId, data)
+	}, header, query)
+	if err != nil {
+		return "", err
+	}
+
+	return v.ETag, nil
+}
@@ -0,0 +1,205 @@
+// Copyright 2015 Chen Xianren. All rights reserved.
+
+package oss
+
+// OSS Versioning Status List
+const (
+	VersioningEnabled   = "Enabled"
+	VersioningSuspended = "Suspended"
+)
+
+// PutVersioning set the versioning status of the bucket.
+//
+// The first optional Params is for Header, the second is for Query.
+//
+// Relevant documentation:
+//
+// https://help.aliyun.com/document_detail/165258.html
+func (b Bucket) PutVersioning(vc VersioningConfiguration, args ...Params) error {
+	header, query := getHeaderQuery(args)
+	query.Set("versioning", "")
+	return b.Do("PUT", "", vc, nil, header, query)
+}
+
+// GetVersioning returns the versioning status of the bucket,
+// the empty string if the versioning has never been enabled.
+//
+// The first optional Params is for Header, the second is for Query.
+//
+// Relevant documentation:
+//
+// https://help.aliyun.com/document_detail/165259.html
+func (b Bucket) GetVersioning(args ...Params) (string, error) {
+	header, query := getHeaderQuery(args)
+	query.Set("versioning", "")
+	var vc VersioningConfiguration
+	err := b.Do("GET", "", nil, &vc, header, query)
+	return vc.Status, err
+}
+
+// ListObjectVersions returns the object versions and the delete markers of the bucket.
+//
+// The first optional Params is for Header, the second is for Query.
+//
+// Query predefine parameters: delimiter, key-marker, version-id-marker, max-keys, prefix, encoding-type.
+//
+// To get all the versions use the method NewVersionIterator.
+//
+// Relevant documentation:
+//
+// https://help.aliyun.com/document_detail/173866.html
+func (b Bucket) ListObjectVersions(args ...Params) (*ListVersionsResult, error) {
+	header, query := getHeaderQuery(args)
+	query.Set("versions", "")
+
+	v := new(ListVersionsResult)
+
+	err := b.Do("GET", "", nil, v, header, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+// DeleteObjectVersions delete multiple objects by the keys and the optional version ids,
+// if not quiet returns the deleted objects and the delete markers.
+//
+// The first optional Params is for Header, the second is for Query.
+//
+// Relevant documentation:
+//
+// https://help.aliyun.com/document_detail/31983.html
+func (b Bucket) DeleteObjectVersions(objects []DeleteObject, quiet bool, args ...Params) (*DeleteResult, error) {
+	header, query := getHeaderQuery(args)
+	query.Set("delete", "")
+
+	d := Delete{
+		Object: objects,
+		Quiet:  quiet,
+	}
+
+	v := new(DeleteResult)
+
+	err := b.Do("POST", "", d, v, header, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+// VersionIterator iterates the pages of the object versions, for example:
+//
+//	it := b.NewVersionIterator()
+//	for it.Next() {
+//		v := it.Result()
+//		// ...
+//	}
+//	if err := it.Err(); err != nil {
+//		// ...
+//	}
+type VersionIterator struct {
+	b      Bucket
+	header Params
+	query  Params
+	result *ListVersionsResult
+	err    error
+	done   bool
+}
+
+// NewVersionIterator returns a new VersionIterator of the bucket,
+// the args are the same as the method ListObjectVersions and are not changed.
+func (b Bucket) NewVersionIterator(args ...Params) *VersionIterator {
+	header, query := getHeaderQuery(args)
+	it := &VersionIterator{b: b, header: Params{}, query: Params{}}
+	it.header.Copy(header)
+	it.query.Copy(query)
+	return it
+}
+
+// Next gets the next page, returns false if no more pages or an error occurred.
+func (it *VersionIterator) Next() bool {
+	if it.done || it.err != nil {
+		return false
+	}
+	it.result, it.err = it.b.ListObjectVersions(it.header, it.query)
+	if it.err != nil {
+		return false
+	}
+	if it.result.IsTruncated {
+		if it.result.NextKeyMarker == "" && it.result.NextVersionIdMarker == "" {
+			it.err = errNextMarkerMissing
+			return false
+		}
+		it.query.Set("key-marker", it.result.NextKeyMarker)
+		it.query.Set("version-id-marker", it.result.NextVersionIdMarker)
+	} else {
+		it.done = true
+	}
+	return true
+}
+
+// Result returns the current page.
+func (it *VersionIterator) Result() *ListVersionsResult {
+	return it.result
+}
+
+// Err returns the error occurred when get the next page.
+func (it *VersionIterator) Err() error {
+	return it.err
+}
+
+// VersioningConfiguration represents the versioning configuration.
+//
+// Relevant documentation:
+//
+// https://help.aliyun.com/document_detail/165258.html
+type VersioningConfiguration struct {
+	Status string `xml:",omitempty"` // Enabled, Suspended
+}
+
+// ObjectVersion represents a version of the object.
+type ObjectVersion struct {
+	Key          string
+	VersionId    string
+	IsLatest     bool
+	LastModified string
+	ETag         string
+	Type         string
+	Size         int64
+	StorageClass string
+	Owner        Owner
+}
+
+// ObjectDeleteMarker represents a delete marker of the object.
+type ObjectDeleteMarker struct {
+	Key          string
+	VersionId    string
+	IsLatest     bool
+	LastModified string
+	Owner        Owner
+}
+
+// ListVersionsResult represents the list object versions result.
+//
+// Relevant documentation:
+//
+// https://help.aliyun.com/document_detail/173866.html
+type ListVersionsResult struct {
+	Name                string
+	Prefix              string
+	KeyMarker           string
+	VersionIdMarker     string
+	NextKeyMarker       string
+	NextVersionIdMarker string
+	MaxKeys             int
+	Delimiter           string
+	IsTruncated         bool
+	Version             []ObjectVersion
+	DeleteMarker        []ObjectDeleteMarker
+	CommonPrefixes      []struct {
+		Prefix string
+	}
+	EncodingType string
+}
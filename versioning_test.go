@@ -0,0 +1,114 @@
+// Copyright 2015 Chen Xianren. All rights reserved.
+
+package oss
+
+import (
+	"encoding/xml"
+	"net/http"
+	"testing"
+)
+
+func TestVersionIterator(t *testing.T) {
+	f, b := newFakeOSS(t)
+	requests := 0
+	f.before = func(w http.ResponseWriter, r *http.Request) bool {
+		requests++
+		v := ListVersionsResult{IsTruncated: true}
+		if requests == 1 {
+			v.NextKeyMarker, v.NextVersionIdMarker = "a", "1"
+		}
+		xml.NewEncoder(w).Encode(v)
+		return true
+	}
+
+	query := Params{"max-keys": {"1"}}
+	it := b.NewVersionIterator(nil, query)
+	pages := 0
+	for it.Next() {
+		pages++
+	}
+	equal(t, "pages", 1, pages)
+	equal(t, "requests", 2, requests)
+	equal(t, "err", errNextMarkerMissing, it.Err())
+	equal(t, "query unchanged", 1, len(query))
+}
+
+func TestBucketVersioning(t *testing.T) {
+	o := newObject()
+	b := o.Bucket
+
+	fatal(t, b.Put())
+
+	status, err := b.GetVersioning()
+	fatal(t, err)
+	equal(t, "versioning", "", status)
+
+	fatal(t, b.PutVersioning(VersioningConfiguration{VersioningEnabled}))
+
+	status, err = b.GetVersioning()
+	fatal(t, err)
+	equal(t, "versioning", VersioningEnabled, status)
+
+	_, err = o.Put([]byte(HelloWorld))
+	fatal(t, err)
+	h, err := o.Head()
+	fatal(t, err)
+	v1 := h.Get("x-oss-version-id")
+
+	_, err = o.Put([]byte(HelloWorld[1:]))
+	fatal(t, err)
+
+	fatal(t, o.Delete())
+
+	var v []byte
+	err = o.Get(&v)
+	e, ok := err.(Error)
+	if !(ok && e.Code == "NoSuchKey") {
+		t.Fatal("expected NoSuchKey")
+	}
+
+	o.VersionId = v1
+	fatal(t, o.Get(&v))
+	if HelloWorld != string(v) {
+		t.Fatal("expected HelloWorld")
+	}
+
+	query := Params{}
+	query.Set("prefix", o.Name)
+	query.Set("max-keys", "1")
+
+	var objects []DeleteObject
+	versions, markers := 0, 0
+	it := b.NewVersionIterator(nil, query)
+	for it.Next() {
+		r := it.Result()
+		for _, i := range r.Version {
+			objects = append(objects, DeleteObject{Key: i.Key, VersionId: i.VersionId})
+			versions++
+		}
+		for _, i := range r.DeleteMarker {
+			objects = append(objects, DeleteObject{Key: i.Key, VersionId: i.VersionId})
+			markers++
+		}
+	}
+	fatal(t, it.Err())
+	equal(t, "versions", 2, versions)
+	equal(t, "delete markers", 1, markers)
+
+	dr, err := b.DeleteObjectVersions(objects, false)
+	fatal(t, err)
+	equal(t, "deleted", 3, len(dr.Deleted))
+	found := false
+	for _, i := range dr.Deleted {
+		if i.DeleteMarker {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatal("expected DeleteMarker")
+	}
+
+	fatal(t, b.PutVersioning(VersioningConfiguration{VersioningSuspended}))
+
+	fatal(t, b.Delete())
+}
@@ -0,0 +1,169 @@
+// Copyright 2015 Chen Xianren. All rights reserved.
+
+package oss
+
+import (
+	"errors"
+)
+
+// OSS WORM State List
+const (
+	WormInProgress = "InProgress"
+	WormLocked     = "Locked"
+)
+
+// OSS WORM Error Code List
+const (
+	CodeNoSuchWORMConfiguration  = "NoSuchWORMConfiguration"
+	CodeInvalidWORMConfiguration = "InvalidWORMConfiguration"
+	CodeWORMConfigurationLocked  = "WORMConfigurationLocked"
+	CodeObjectImmutable          = "ObjectImmutable"
+)
+
+// The retention period is 1 to 25550 days (70 years).
+const (
+	WormMinRetentionDays = 1
+	WormMaxRetentionDays = 25550
+)
+
+// HeaderWormId is the response header of the initiated WORM id.
+const HeaderWormId = "x-oss-worm-id"
+
+var (
+	errWormIdRequired       = errors.New("worm id required")
+	errWormRetentionInvalid = errors.New("worm retention period invalid")
+)
+
+func checkWormRetention(days int) error {
+	if days < WormMinRetentionDays || days > WormMaxRetentionDays {
+		return errWormRetentionInvalid
+	}
+	return nil
+}
+
+// InitiateWorm create a WORM (Write Once Read Many) retention policy of the bucket
+// given the retention period in days, returns the WORM id.
+//
+// The first optional Params is for Header, the second is for Query.
+//
+// The policy is InProgress for 24 hours, in which it can be aborted or completed (locked),
+// it is deleted if not completed.
+//
+// Relevant documentation:
+//
+// https://help.aliyun.com/document_detail/188071.html
+func (b Bucket) InitiateWorm(days int, args ...Params) (string, error) {
+	if err := checkWormRetention(days); err != nil {
+		return "", err
+	}
+
+	header, query := getHeaderQuery(args)
+	query.Set("worm", "")
+
+	res, err := b.GetResponse("POST", "", InitiateWormConfiguration{days}, header, query)
+	if err != nil {
+		return "", err
+	}
+
+	err = ReadBody(res, nil)
+	if err != nil {
+		return "", err
+	}
+
+	return res.Header.Get(HeaderWormId), nil
+}
+
+// AbortWorm delete the InProgress WORM retention policy of the bucket,
+// returns WORMConfigurationLocked Error if the policy is Locked.
+//
+// The first optional Params is for Header, the second is for Query.
+//
+// Relevant documentation:
+//
+// https://help.aliyun.com/document_detail/188072.html
+func (b Bucket) AbortWorm(args ...Params) error {
+	header, query := getHeaderQuery(args)
+	query.Set("worm", "")
+	return b.Do("DELETE", "", nil, nil, header, query)
+}
+
+// CompleteWorm lock the InProgress WORM retention policy of the bucket given the WORM id,
+// the Locked policy can not be deleted and the retention period can only be extended.
+//
+// The first optional Params is for Header, the second is for Query.
+//
+// Relevant documentation:
+//
+// https://help.aliyun.com/document_detail/188073.html
+func (b Bucket) CompleteWorm(wormId string, args ...Params) error {
+	if wormId == "" {
+		return errWormIdRequired
+	}
+	header, query := getHeaderQuery(args)
+	query.Set("wormId", wormId)
+	return b.Do("POST", "", nil, nil, header, query)
+}
+
+// ExtendWorm extend the retention period of the Locked WORM retention policy given the WORM id and the days.
+//
+// The first optional Params is for Header, the second is for Query.
+//
+// Relevant documentation:
+//
+// https://help.aliyun.com/document_detail/188074.html
+func (b Bucket) ExtendWorm(wormId string, days int, args ...Params) error {
+	if wormId == "" {
+		return errWormIdRequired
+	}
+	if err := checkWormRetention(days); err != nil {
+		return err
+	}
+	header, query := getHeaderQuery(args)
+	query.Set("wormExtend", "")
+	query.Set("wormId", wormId)
+	return b.Do("POST", "", ExtendWormConfiguration{days}, nil, header, query)
+}
+
+// GetWorm returns the WORM retention policy of the bucket,
+// returns NoSuchWORMConfiguration Error if not exists.
+//
+// The first optional Params is for Header, the second is for Query.
+//
+// Relevant documentation:
+//
+// https://help.aliyun.com/document_detail/188075.html
+func (b Bucket) GetWorm(args ...Params) (*WormConfiguration, error) {
+	header, query := getHeaderQuery(args)
+	query.Set("worm", "")
+
+	v := new(WormConfiguration)
+
+	err := b.Do("GET", "", nil, v, header, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+// InitiateWormConfiguration represents the initiate WORM configuration.
+type InitiateWormConfiguration struct {
+	RetentionPeriodInDays int
+}
+
+// ExtendWormConfiguration represents the extend WORM configuration.
+type ExtendWormConfiguration struct {
+	RetentionPeriodInDays int
+}
+
+// WormConfiguration represents the WORM retention policy.
+//
+// Relevant documentation:
+//
+// https://help.aliyun.com/document_detail/188075.html
+type WormConfiguration struct {
+	WormId                string
+	State                 string // InProgress, Locked
+	RetentionPeriodInDays int
+	CreationDate          string
+}
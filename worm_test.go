@@ -0,0 +1,38 @@
+// Copyright 2015 Chen Xianren. All rights reserved.
+
+package oss
+
+import (
+	"testing"
+)
+
+func TestBucketWorm(t *testing.T) {
+	b := newBucket()
+
+	_, err := b.InitiateWorm(0)
+	equal(t, "InitiateWorm", errWormRetentionInvalid, err)
+
+	fatal(t, b.Put())
+
+	_, err = b.GetWorm()
+	equal(t, "GetWorm", CodeNoSuchWORMConfiguration, ErrorCode(err))
+
+	id, err := b.InitiateWorm(1)
+	fatal(t, err)
+	if id == "" {
+		t.Fatal("expected WormId")
+	}
+
+	v, err := b.GetWorm()
+	fatal(t, err)
+	equal(t, "WormId", id, v.WormId)
+	equal(t, "State", WormInProgress, v.State)
+	equal(t, "RetentionPeriodInDays", 1, v.RetentionPeriodInDays)
+
+	fatal(t, b.AbortWorm())
+
+	_, err = b.GetWorm()
+	equal(t, "GetWorm", CodeNoSuchWORMConfiguration, ErrorCode(err))
+
+	fatal(t, b.Delete())
+}
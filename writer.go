@@ -0,0 +1,234 @@
+// Copyright 2015 Chen Xianren. All rights reserved.
+
+package oss
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+var errWriterClosed = errors.New("object writer closed")
+
+// ObjectWriter implements the io.WriteCloser of the object.
+//
+// The written data is buffered into parts of the partSize, the first full part initiates a Multipart Upload
+// and at most routines parts are uploaded in parallel, the Write blocks if all the routines are busy.
+// The Close uploads the last part and completes the Multipart Upload,
+// or sends the data by the method Put if the total size is not greater than the partSize.
+//
+// The parts are sent with the context, so the uploading parts are canceled when the context is done.
+// The Multipart Upload is aborted by the Write or the Close after any part failed or the context is done,
+// then they return the error, so always call the Close to release the OSS space of the uploaded parts.
+//
+// It is not safe for concurrent use, the object is not written until the Close returns nil.
+type ObjectWriter struct {
+	o        Object
+	ctx      context.Context
+	header   Params
+	query    Params
+	partSize int64
+
+	buf      []byte
+	uploadId string
+	sem      chan struct{}
+	wg       sync.WaitGroup
+	closed   bool
+	etag     string
+
+	mu    sync.Mutex
+	parts []CompleteMultipartUploadPart
+	err   error
+}
+
+// NewWriter returns the ObjectWriter of the object.
+//
+// The first optional Params is for Header, the second is for Query,
+// both are sent with the Put or the InitiateMultipartUpload.
+//
+// The MultipartUploadPartSize is used if the partSize <= 0,
+// the total size is limited to 10000 parts since it is unknown in advance.
+func (o Object) NewWriter(ctx context.Context, partSize int64, routines int, args ...Params) *ObjectWriter {
+	if partSize <= 0 {
+		partSize = MultipartUploadPartSize
+	} else if partSize < MultipartUploadMinPartSize {
+		partSize = MultipartUploadMinPartSize
+	}
+	if routines <= 0 {
+		routines = 1
+	}
+
+	header, query := getHeaderQuery(args)
+	w := &ObjectWriter{
+		o:        o,
+		ctx:      ctx,
+		header:   Params{},
+		query:    Params{},
+		partSize: partSize,
+		sem:      make(chan struct{}, routines),
+	}
+	w.header.Copy(header)
+	w.query.Copy(query)
+
+	return w
+}
+
+// args returns the copies of the Header and the Query for a request.
+func (w *ObjectWriter) args() (Params, Params) {
+	header, query := Params{}, Params{}
+	header.Copy(w.header)
+	query.Copy(w.query)
+	return header, query
+}
+
+// ETag returns the object ETag after the Close returns nil.
+func (w *ObjectWriter) ETag() string {
+	return w.etag
+}
+
+// fail records the first error.
+func (w *ObjectWriter) fail(err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.err == nil {
+		w.err = err
+	}
+}
+
+// check returns the recorded error or the context error.
+func (w *ObjectWriter) check() error {
+	if err := w.ctx.Err(); err != nil {
+		w.fail(err)
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.err
+}
+
+// abort waits for the uploading parts and aborts the Multipart Upload if it is initiated.
+func (w *ObjectWriter) abort() {
+	w.wg.Wait()
+	if w.uploadId != "" {
+		w.o.AbortMultipartUpload(w.uploadId)
+		w.uploadId = ""
+	}
+}
+
+// upload the data as the next part in background, initiates the Multipart Upload first if not yet.
+func (w *ObjectWriter) upload(data []byte) error {
+	if w.uploadId == "" {
+		header, query := w.args()
+		if w.o.ACL != "" {
+			header.Set("x-oss-object-acl", w.o.ACL)
+		}
+		imu, err := w.o.InitiateMultipartUpload(header, query)
+		if err != nil {
+			return err
+		}
+		w.uploadId = imu.UploadId
+	}
+
+	select {
+	case w.sem <- struct{}{}:
+	case <-w.ctx.Done():
+		return w.ctx.Err()
+	}
+
+	w.mu.Lock()
+	i := len(w.parts)
+	w.parts = append(w.parts, CompleteMultipartUploadPart{})
+	w.mu.Unlock()
+
+	w.wg.Add(1)
+	go func(uploadId string) {
+		defer func() {
+			<-w.sem
+			w.wg.Done()
+		}()
+
+		o := w.o
+		o.Service = o.Service.WithContext(w.ctx)
+		etag, err := o.UploadPart(i+1, uploadId, data)
+		if err != nil {
+			w.fail(err)
+			return
+		}
+
+		w.mu.Lock()
+		w.parts[i] = CompleteMultipartUploadPart{i + 1, etag}
+		w.mu.Unlock()
+	}(w.uploadId)
+
+	return nil
+}
+
+// Write implements the io.Writer, uploads the full parts of the buffered data.
+//
+// If it failed, the returned n is the number of the bytes of p in the parts which are sent,
+// the rest of the buffered data is dropped.
+func (w *ObjectWriter) Write(p []byte) (int, error) {
+	if w.closed {
+		return 0, errWriterClosed
+	}
+	if err := w.check(); err != nil {
+		w.abort()
+		return 0, err
+	}
+
+	n := -len(w.buf) // the sent bytes of p, the buffered data is sent first
+	w.buf = append(w.buf, p...)
+	for int64(len(w.buf)) > w.partSize {
+		data := make([]byte, w.partSize)
+		copy(data, w.buf)
+		if err := w.upload(data); err != nil {
+			w.fail(err)
+			w.abort()
+			w.buf = nil
+			if n < 0 {
+				n = 0
+			}
+			return n, err
+		}
+		w.buf = w.buf[:copy(w.buf, w.buf[w.partSize:])]
+		n += int(w.partSize)
+	}
+	return len(p), nil
+}
+
+// Close implements the io.Closer, completes the upload and returns the error if failed.
+func (w *ObjectWriter) Close() error {
+	if w.closed {
+		return errWriterClosed
+	}
+	w.closed = true
+
+	err := w.check()
+	if err == nil && w.uploadId == "" {
+		header, query := w.args()
+		w.etag, err = w.o.Put(w.buf, header, query)
+		w.buf = nil
+		return err
+	}
+
+	if err == nil {
+		if err = w.upload(w.buf); err != nil {
+			w.fail(err)
+		}
+		w.buf = nil
+		w.wg.Wait()
+		err = w.check()
+	}
+	if err != nil {
+		w.abort()
+		return err
+	}
+
+	v, err := w.o.CompleteMultipartUpload(w.uploadId, CompleteMultipartUpload{Part: w.parts})
+	if err != nil {
+		w.abort()
+		return err
+	}
+	w.uploadId = ""
+	w.etag = v.ETag
+	return nil
+}
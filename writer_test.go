@@ -0,0 +1,125 @@
+// Copyright 2015 Chen Xianren. All rights reserved.
+
+package oss
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"testing"
+)
+
+func TestObjectWriter(t *testing.T) {
+	f, b := newFakeOSS(t)
+	ctx := context.Background()
+	data := bytes.Repeat([]byte(HelloWorld), MultipartUploadMinPartSize/4)
+	object := func(key string) []byte {
+		v, _ := f.get(key)
+		return v
+	}
+
+	w := b.NewObject("small").NewWriter(ctx, 0, 2)
+	_, err := io.WriteString(w, HelloWorld)
+	fatal(t, err)
+	fatal(t, w.Close())
+	equal(t, "small", HelloWorld, string(object("small")))
+	equal(t, "small etag", fakeETag([]byte(HelloWorld)), w.ETag())
+	f.mu.Lock()
+	equal(t, "small uploads", 0, f.nextId)
+	f.mu.Unlock()
+
+	w = b.NewObject("large").NewWriter(ctx, MultipartUploadMinPartSize, 3)
+	for r := bytes.NewBuffer(data); r.Len() > 0; {
+		_, err = w.Write(r.Next(MultipartUploadMinPartSize/3 + 7))
+		fatal(t, err)
+	}
+	fatal(t, w.Close())
+	equal(t, "large", true, bytes.Equal(data, object("large")))
+	equal(t, "large etag", `"MULTIPART"`, w.ETag())
+	f.mu.Lock()
+	equal(t, "large uploads", 0, len(f.uploads))
+	f.mu.Unlock()
+	equal(t, "closed", errWriterClosed, w.Close())
+
+	ctx, cancel := context.WithCancel(ctx)
+	w = b.NewObject("canceled").NewWriter(ctx, MultipartUploadMinPartSize, 2)
+	_, err = w.Write(data)
+	fatal(t, err)
+	f.mu.Lock()
+	equal(t, "initiated", 1, len(f.uploads))
+	f.mu.Unlock()
+	cancel()
+	_, err = w.Write(data)
+	equal(t, "canceled", context.Canceled, err)
+	equal(t, "canceled close", context.Canceled, w.Close())
+	f.mu.Lock()
+	equal(t, "aborted", 0, len(f.uploads))
+	f.mu.Unlock()
+	_, ok := f.get("canceled")
+	equal(t, "canceled object", false, ok)
+
+	ctx, cancel = context.WithCancel(context.Background())
+	f.mu.Lock()
+	f.before = func(w http.ResponseWriter, r *http.Request) bool {
+		if r.URL.Query().Get("partNumber") == "1" {
+			ioutil.ReadAll(r.Body) // the server notices the closed connection after the body is read
+			cancel()
+			<-r.Context().Done() // until the client cancels the request
+			return true
+		}
+		return false
+	}
+	f.mu.Unlock()
+	w = b.NewObject("canceled").NewWriter(ctx, MultipartUploadMinPartSize, 2)
+	_, err = w.Write(data[:MultipartUploadMinPartSize+1])
+	if err == nil {
+		err = w.Close()
+	}
+	equal(t, "in-flight canceled", true, errors.Is(err, context.Canceled))
+	f.mu.Lock()
+	f.before = nil
+	equal(t, "in-flight aborted", 0, len(f.uploads))
+	f.mu.Unlock()
+
+	ctx, cancel = context.WithCancel(context.Background())
+	release := make(chan struct{})
+	f.mu.Lock()
+	f.before = func(w http.ResponseWriter, r *http.Request) bool {
+		if r.URL.Query().Get("partNumber") == "1" {
+			cancel()
+			<-release // hold the only routine so the next part fails by the context
+			return true
+		}
+		return false
+	}
+	f.mu.Unlock()
+	w = b.NewObject("canceled").NewWriter(ctx, MultipartUploadMinPartSize, 1)
+	n, err := w.Write(data[:2*MultipartUploadMinPartSize+1])
+	close(release)
+	equal(t, "partial write", MultipartUploadMinPartSize, n)
+	equal(t, "partial write canceled", context.Canceled, err)
+	equal(t, "partial write close", context.Canceled, w.Close())
+	f.mu.Lock()
+	f.before = nil
+	equal(t, "partial write aborted", 0, len(f.uploads))
+	f.mu.Unlock()
+
+	w = b.NewObject("failed").NewWriter(context.Background(), MultipartUploadMinPartSize, 1)
+	_, err = w.Write(data[:MultipartUploadMinPartSize+1])
+	fatal(t, err)
+	f.mu.Lock()
+	f.uploads = map[string]map[int][]byte{}
+	f.mu.Unlock()
+	_, err = w.Write(data[MultipartUploadMinPartSize+1:])
+	if err == nil {
+		err = w.Close()
+	}
+	if e, ok := err.(Error); !ok || e.Code != "NoSuchUpload" {
+		t.Fatal("want NoSuchUpload, got", err)
+	}
+	_, ok = f.get("failed")
+	equal(t, "failed object", false, ok)
+}